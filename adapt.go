@@ -5,7 +5,6 @@ package wasmgl
 import (
 	"runtime"
 	"syscall/js"
-	"unsafe"
 )
 
 var (
@@ -21,13 +20,12 @@ var (
 	uint32Array  js.Value
 	float32Array js.Value
 
-	interfaceSlice []any
-)
+	// NOTE: Views of specific lengths are cached, since enum sequences
+	// need to have an exact length and creating a new view for each call
+	// is wasteful.
 
-// DataTypes represents allowed data slice types.
-type DataTypes interface {
-	~int8 | ~uint8 | ~int16 | ~uint16 | ~int32 | ~uint32 | ~float32 | ~float64
-}
+	uint32Views []js.Value
+)
 
 // ensureBufferSize ensures that the global ArrayBuffer has a size
 // that is equal or larger to the specified size.
@@ -40,6 +38,7 @@ func ensureBufferSize(size int) {
 		int32Array = js.Global().Get("Int32Array").New(arrayBuffer)
 		uint32Array = js.Global().Get("Uint32Array").New(arrayBuffer)
 		float32Array = js.Global().Get("Float32Array").New(arrayBuffer)
+		uint32Views = uint32Views[:0]
 	}
 }
 
@@ -67,57 +66,91 @@ func popBufferData[T DataTypes](data []T) {
 	runtime.KeepAlive(data)
 }
 
-// getFunction retrieves the function with the specified name
-// from the specified target object. It returns a binding to that
-// function that has target set as the function's 'this'.
-func getFunction(target js.Value, name string) js.Value {
-	return target.Get(name).Call("bind", target)
+// onPromise calls the specified function once the specified promise has
+// been settled, with either its value or the reason for its rejection.
+// The callbacks are released afterwards.
+func onPromise(promise js.Value, fn func(value js.Value, err error)) {
+	var onFulfilled, onRejected js.Func
+	release := func() {
+		onFulfilled.Release()
+		onRejected.Release()
+	}
+	onFulfilled = js.FuncOf(func(this js.Value, args []js.Value) any {
+		release()
+		fn(firstArg(args), nil)
+		return nil
+	})
+	onRejected = js.FuncOf(func(this js.Value, args []js.Value) any {
+		release()
+		fn(js.Undefined(), js.Error{Value: firstArg(args)})
+		return nil
+	})
+	promise.Call("then", onFulfilled, onRejected)
 }
 
-// asByteSlice returns a []byte representation for the
-// specified arbitrary slice type.
-//
-// This utility function is related to the following issues:
-// https://github.com/golang/go/issues/32402
-// https://github.com/golang/go/issues/31980
-func asByteSlice[T DataTypes](data []T) []byte {
-	if len(data) == 0 {
-		return nil
-	}
-	dataSize := byteSize(data)
-	return unsafe.Slice((*byte)(unsafe.Pointer(&data[0])), dataSize)
+// blobBytes reads the contents of the specified Blob and passes them to
+// the specified function.
+func blobBytes(blob js.Value, fn func(data []byte, err error)) {
+	onPromise(blob.Call("arrayBuffer"), func(value js.Value, err error) {
+		if err != nil {
+			fn(nil, err)
+			return
+		}
+		view := js.Global().Get("Uint8Array").New(value)
+		data := make([]byte, view.Length())
+		js.CopyBytesToGo(data, view)
+		fn(data, nil)
+	})
 }
 
-// byteSize returns the number of bytes that would be
-// needed to represent data once it is converted to a
-// byte slice through asByteSlice.
-func byteSize[T DataTypes](data []T) int {
-	if len(data) == 0 {
-		return 0
+func firstArg(args []js.Value) js.Value {
+	if len(args) == 0 {
+		return js.Undefined()
 	}
-	return len(data) * int(unsafe.Sizeof(data[0]))
+	return args[0]
 }
 
-// ensureSliceSize ensures that the global interfaceSlice has
-// a size equal or larger than the specified size.
-func ensureSliceSize(size int) {
-	if size > len(interfaceSlice) {
-		oldSlice := interfaceSlice
-		interfaceSlice = make([]any, size)
-		copy(interfaceSlice, oldSlice)
+// pixelView returns the view of the global ArrayBuffer that matches the
+// specified pixel data type, since WebGL2 requires the type of the view to
+// correspond to the type of the pixel data.
+func pixelView(dtype GLenum) js.Value {
+	switch dtype {
+	case FLOAT:
+		return float32Array
+	case INT:
+		return int32Array
+	case UNSIGNED_INT, UNSIGNED_INT_2_10_10_10_REV, UNSIGNED_INT_10F_11F_11F_REV,
+		UNSIGNED_INT_5_9_9_9_REV, UNSIGNED_INT_24_8:
+		return uint32Array
+	case UNSIGNED_SHORT, UNSIGNED_SHORT_5_6_5, UNSIGNED_SHORT_4_4_4_4,
+		UNSIGNED_SHORT_5_5_5_1, HALF_FLOAT:
+		return uint16Array
+	default:
+		return uint8Array
 	}
 }
 
-// pushSliceData inserts the specified data into the global
-// interfaceSlice at the specified offset and returns a view
-// on only the inserted data.
+// getFunction retrieves the function with the specified name
+// from the specified target object. It returns a binding to that
+// function that has target set as the function's 'this'.
+func getFunction(target js.Value, name string) js.Value {
+	return target.Get(name).Call("bind", target)
+}
+
+// pushEnumData inserts the specified enums into the global
+// ArrayBuffer and returns a Uint32Array view that covers exactly
+// the inserted data, to be used for WebGL2 calls that take a
+// sequence of enums.
 //
-// The offset parameter allows one to get two or more views over the
-// global interfaceSlice if needed.
-func pushSliceData[T any](data []T, offset int) []any {
-	ensureSliceSize(offset + len(data))
-	for i, v := range data {
-		interfaceSlice[i+offset] = v
+// Unlike converting a []any, this does not allocate once a view
+// of the respective length has been created.
+func pushEnumData(data []GLenum) js.Value {
+	// NOTE: Even an empty sequence needs the global ArrayBuffer
+	// to have been created.
+	ensureBufferSize(4 * max(len(data), 1))
+	pushBufferData(data)
+	for len(uint32Views) <= len(data) {
+		uint32Views = append(uint32Views, uint32Array.Call("subarray", 0, len(uint32Views)))
 	}
-	return interfaceSlice[offset : offset+len(data)]
+	return uint32Views[len(data)]
 }
@@ -0,0 +1,196 @@
+package wasmgl
+
+var backend Backend
+
+// Backend represents an implementation of the WebGL2 API. All package-level
+// functions forward their calls to the currently active Backend.
+//
+// The default Backend is configured by InitFromID and InitFromCanvas and
+// uses syscall/js to call into the browser's WebGL2 context. Alternative
+// implementations (e.g. for unit testing) can be installed through
+// SetBackend.
+type Backend interface {
+	ActiveTexture(texture GLenum)
+	AttachShader(program Program, shader Shader)
+	BindBuffer(target GLenum, buffer Buffer)
+	BindBufferBase(target GLenum, index GLuint, buffer Buffer)
+	BindBufferRange(target GLenum, index GLuint, buffer Buffer, offset GLintptr, size GLsizeiptr)
+	BindFramebuffer(target GLenum, framebuffer Framebuffer)
+	BindRenderbuffer(target GLenum, renderbuffer Renderbuffer)
+	BindSampler(unit GLuint, sampler Sampler)
+	BindTexture(target GLenum, texture Texture)
+	BindVertexArray(array VertexArray)
+	BlendColor(red, green, blue, alpha GLclampf)
+	BlendEquation(mode GLenum)
+	BlendEquationSeparate(modeRGB, modeAlpha GLenum)
+	BlendFunc(sfactor, dfactor GLenum)
+	BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha GLenum)
+	BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1 GLint, mask GLbitfield, filter GLenum)
+	BufferData(target GLenum, size GLsizeiptr, data []byte, usage GLenum)
+	BufferSubData(target GLenum, dstOffset GLintptr, data []byte)
+	CheckFramebufferStatus(target GLenum) GLenum
+	Clear(mask GLbitfield)
+	ClearBufferfv(buffer GLenum, drawBuffer GLint, values Float32List)
+	ClearBufferfvOffset(buffer GLenum, drawBuffer GLint, values Float32List, srcOffset GLuint)
+	ClearBufferiv(buffer GLenum, drawBuffer GLint, values Int32List)
+	ClearBufferivOffset(buffer GLenum, drawBuffer GLint, values Int32List, srcOffset GLuint)
+	ClearBufferuiv(buffer GLenum, drawBuffer GLint, values Uint32List)
+	ClearBufferuivOffset(buffer GLenum, drawBuffer GLint, values Uint32List, srcOffset GLuint)
+	ClearBufferfi(buffer GLenum, drawBuffer GLint, depth GLfloat, stencil GLint)
+	ClearColor(r, g, b, a GLclampf)
+	ClearDepth(depth GLclampf)
+	ClearStencil(stencil GLint)
+	ClientWaitSync(sync Sync, flags GLbitfield, timeout GLuint64) GLenum
+	ColorMask(r, g, b, a GLboolean)
+	CompileShader(shader Shader)
+	CompressedTexImage2D(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, data []byte)
+	CompressedTexImage2DOffset(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, imageSize GLsizei, offset GLintptr)
+	CompressedTexImage3D(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, data []byte)
+	CompressedTexImage3DOffset(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, imageSize GLsizei, offset GLintptr)
+	CompressedTexSubImage2D(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format GLenum, data []byte)
+	CompressedTexSubImage2DOffset(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format GLenum, imageSize GLsizei, offset GLintptr)
+	CompressedTexSubImage3D(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format GLenum, data []byte)
+	CompressedTexSubImage3DOffset(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format GLenum, imageSize GLsizei, offset GLintptr)
+	CopyBufferSubData(readTarget, writeTarget GLenum, readOffset, writeOffset GLintptr, size GLsizeiptr)
+	CopyTexImage2D(target GLenum, level GLint, internalFormat GLenum, x, y GLint, width, height GLsizei, border GLint)
+	CopyTexSubImage2D(target GLenum, level, xoffset, yoffset, x, y GLint, width, height GLsizei)
+	CopyTexSubImage3D(target GLenum, level, xoffset, yoffset, zoffset, x, y GLint, width, height GLsizei)
+	CreateBuffer() Buffer
+	CreateFramebuffer() Framebuffer
+	CreateProgram() Program
+	CreateRenderbuffer() Renderbuffer
+	CreateSampler() Sampler
+	CreateShader(shaderType GLenum) Shader
+	CreateTexture() Texture
+	CreateVertexArray() VertexArray
+	CullFace(mode GLenum)
+	DeleteBuffer(buffer Buffer)
+	DeleteFramebuffer(framebuffer Framebuffer)
+	DeleteProgram(program Program)
+	DeleteRenderbuffer(renderbuffer Renderbuffer)
+	DeleteSampler(sampler Sampler)
+	DeleteShader(shader Shader)
+	DeleteSync(sync Sync)
+	DeleteTexture(texture Texture)
+	DeleteVertexArray(array VertexArray)
+	DepthFunc(fn GLenum)
+	DepthMask(mask GLboolean)
+	DepthRange(zNear, zFar GLclampf)
+	DetachShader(program Program, shader Shader)
+	Disable(cap GLenum)
+	DisableVertexAttribArray(index GLuint)
+	DrawArrays(mode GLenum, first GLint, count GLsizei)
+	DrawArraysInstanced(mode GLenum, first GLint, count, instanceCount GLsizei)
+	DrawBuffers(buffers []GLenum)
+	DrawElements(mode GLenum, count GLsizei, dtype GLenum, offset GLintptr)
+	DrawElementsInstanced(mode GLenum, count GLsizei, pType GLenum, offset GLintptr, instanceCount GLsizei)
+	DrawingBufferColorSpace() ColorSpace
+	DrawingBufferFormat() GLenum
+	DrawingBufferHeight() int
+	DrawingBufferStorage(internalFormat GLenum, width, height GLsizei)
+	DrawingBufferWidth() int
+	Enable(cap GLenum)
+	EnableVertexAttribArray(index GLuint)
+	Finish()
+	Flush()
+	FramebufferRenderbuffer(target, attachment, renderbufferTarget GLenum, renderbuffer Renderbuffer)
+	FramebufferTexture2D(target, attachment, texTarget GLenum, texture Texture, level GLint)
+	FramebufferTextureLayer(target, attachment GLenum, texture Texture, level, layer GLint)
+	FrontFace(mode GLenum)
+	FenceSync(condition GLenum, flags GLbitfield) Sync
+	GenerateMipmap(target GLenum)
+	GetAttachedShaders(program Program) []Shader
+	GetAttribLocation(program Program, name string) GLint
+	GetBufferSubData(target GLenum, srcOffset GLintptr, data []byte)
+	GetError() GLenum
+	GetExtension(name string) any
+	GetParameter(name GLenum) Any
+	GetProgramInfoLog(program Program) string
+	GetProgramParameter(program Program, pname GLenum) Any
+	GetSamplerParameter(sampler Sampler, pname GLenum) Any
+	GetShaderInfoLog(shader Shader) string
+	GetShaderParameter(shader Shader, pname GLenum) Any
+	GetShaderPrecisionFormat(shaderType, precisionType GLenum) ShaderPrecisionFormat
+	GetShaderSource(shader Shader) string
+	GetSyncParameter(sync Sync, pname GLenum) Any
+	GetUniformBlockIndex(program Program, name string) GLuint
+	GetUniformLocation(program Program, name string) UniformLocation
+	Hint(target, mode GLenum)
+	InvalidateFramebuffer(target GLenum, attachments []GLenum)
+	InvalidateSubFramebuffer(target GLenum, attachments []GLenum, x, y GLint, width, height GLsizei)
+	IsBuffer(buffer Buffer) bool
+	IsContextLost() bool
+	IsEnabled(cap GLenum) bool
+	IsFramebuffer(framebuffer Framebuffer) bool
+	IsProgram(program Program) bool
+	IsQuery(query Query) bool
+	IsRenderbuffer(renderbuffer Renderbuffer) bool
+	IsSampler(sampler Sampler) bool
+	IsShader(shader Shader) bool
+	IsSync(sync Sync) bool
+	IsTexture(texture Texture) bool
+	IsVertexArray(array VertexArray) bool
+	LineWidth(width GLfloat)
+	LinkProgram(program Program)
+	PixelStorei(pname GLenum, param GLint)
+	PolygonOffset(factor, units GLfloat)
+	ReadBuffer(src GLenum)
+	ReadPixels(x, y GLint, width, height GLsizei, format, dtype GLenum, offset GLintptr)
+	ReadPixelsData(x, y GLint, width, height GLsizei, format, dtype GLenum, data []byte)
+	RenderbufferStorage(target, internalFormat GLenum, width, height GLsizei)
+	RenderbufferStorageMultisample(target GLenum, samples GLsizei, internalFormat GLenum, width, height GLsizei)
+	SampleCoverage(value GLclampf, invert GLboolean)
+	SamplerParameterf(sampler Sampler, pname GLenum, param GLfloat)
+	SamplerParameteri(sampler Sampler, pname GLenum, param GLint)
+	Scissor(x, y GLint, width, height GLsizei)
+	SetDrawingBufferColorSpace(colorSpace ColorSpace)
+	SetUnpackColorSpace(colorSpace ColorSpace)
+	ShaderSource(shader Shader, source string)
+	StencilFunc(fun GLenum, ref GLint, mask GLuint)
+	StencilFuncSeparate(face, fun GLenum, ref GLint, mask GLuint)
+	StencilMask(mask GLuint)
+	StencilMaskSeparate(face GLenum, mask GLuint)
+	StencilOp(fail, zfail, zpass GLenum)
+	StencilOpSeparate(face, fail, zfail, zpass GLenum)
+	TexImage2D(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, data []byte)
+	TexImage2DOffset(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, offset GLintptr)
+	TexImage3D(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, data []byte)
+	TexImage3DOffset(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, offset GLintptr)
+	TexParameterf(target, pname GLenum, param GLfloat)
+	TexStorage2D(target GLenum, levels GLsizei, internalFormat GLenum, width, height GLsizei)
+	TexStorage3D(target GLenum, levels GLsizei, internalFormat GLenum, width, height, depth GLsizei)
+	TexSubImage2D(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format, dtype GLenum, data []byte)
+	TexSubImage2DOffset(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format, dtype GLenum, offset GLintptr)
+	TexSubImage3D(target GLenum, level GLint, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format, dtype GLenum, data []byte)
+	TexParameteri(target, pname GLenum, param GLint)
+	TexSubImage3DOffset(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format, dtype GLenum, offset GLintptr)
+	Uniform1f(location UniformLocation, x GLfloat)
+	Uniform1i(location UniformLocation, x GLint)
+	Uniform2f(location UniformLocation, x, y GLfloat)
+	Uniform2i(location UniformLocation, x, y GLint)
+	Uniform3f(location UniformLocation, x, y, z GLfloat)
+	Uniform3i(location UniformLocation, x, y, z GLint)
+	Uniform4f(location UniformLocation, x, y, z, w GLfloat)
+	Uniform4i(location UniformLocation, x, y, z, w GLint)
+	UniformBlockBinding(program Program, index, binding GLuint)
+	UniformMatrix4fv(location UniformLocation, transpose GLboolean, data []GLfloat)
+	UnpackColorSpace() ColorSpace
+	UseProgram(program Program)
+	ValidateProgram(program Program)
+	VertexAttribIPointer(index GLuint, size GLint, dtype GLenum, stride GLsizei, offset GLintptr)
+	VertexAttribPointer(index GLuint, size GLint, dtype GLenum, normalized GLboolean, stride GLsizei, offset GLintptr)
+	Viewport(x, y GLint, width, height GLsizei)
+	WaitSync(sync Sync, flags GLbitfield, timeout GLint64)
+}
+
+// SetBackend configures the Backend that will be used by all package-level
+// functions.
+func SetBackend(b Backend) {
+	backend = b
+}
+
+// CurrentBackend returns the Backend that is currently in use. The result is
+// nil if no Backend has been configured yet.
+func CurrentBackend() Backend {
+	return backend
+}
@@ -0,0 +1,1093 @@
+//go:build js && wasm
+
+package wasmgl
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+var (
+	// WebGL1 functions:
+	// 	- https://www.khronos.org/registry/webgl/specs/latest/1.0/
+	// 	- https://developer.mozilla.org/en-US/docs/Web/API/WebGLRenderingContext
+
+	// WebGL2 functions
+	// 	- https://www.khronos.org/registry/webgl/specs/latest/2.0/
+	// 	- https://developer.mozilla.org/en-US/docs/Web/API/WebGL2RenderingContext
+
+	// NOTE: We use references to JS functions and Invoke instead of
+	// Call since the latter leads to strings being passed around
+	// and TextDecoder being used on JS side.
+
+	fnActiveTexture                  js.Value
+	fnAttachShader                   js.Value
+	fnBindBuffer                     js.Value
+	fnBindBufferBase                 js.Value
+	fnBindBufferRange                js.Value
+	fnBindFramebuffer                js.Value
+	fnBindRenderbuffer               js.Value
+	fnBindSampler                    js.Value
+	fnBindTexture                    js.Value
+	fnBindVertexArray                js.Value
+	fnBlendColor                     js.Value
+	fnBlendEquation                  js.Value
+	fnBlendEquationSeparate          js.Value
+	fnBlendFunc                      js.Value
+	fnBlendFuncSeparate              js.Value
+	fnBlitFramebuffer                js.Value
+	fnBufferData                     js.Value
+	fnBufferSubData                  js.Value
+	fnCheckFramebufferStatus         js.Value
+	fnClear                          js.Value
+	fnClearBufferfv                  js.Value
+	fnClearBufferiv                  js.Value
+	fnClearBufferuiv                 js.Value
+	fnClearBufferfi                  js.Value
+	fnClearColor                     js.Value
+	fnClearDepth                     js.Value
+	fnClearStencil                   js.Value
+	fnClientWaitSync                 js.Value
+	fnColorMask                      js.Value
+	fnCompileShader                  js.Value
+	fnCompressedTexImage2D           js.Value
+	fnCompressedTexImage3D           js.Value
+	fnCompressedTexSubImage2D        js.Value
+	fnCompressedTexSubImage3D        js.Value
+	fnCopyBufferSubData              js.Value
+	fnCopyTexImage2D                 js.Value
+	fnCopyTexSubImage2D              js.Value
+	fnCopyTexSubImage3D              js.Value
+	fnCreateBuffer                   js.Value
+	fnCreateFramebuffer              js.Value
+	fnCreateProgram                  js.Value
+	fnCreateRenderbuffer             js.Value
+	fnCreateSampler                  js.Value
+	fnCreateShader                   js.Value
+	fnCreateTexture                  js.Value
+	fnCreateVertexArray              js.Value
+	fnCullFace                       js.Value
+	fnDeleteBuffer                   js.Value
+	fnDeleteFramebuffer              js.Value
+	fnDeleteProgram                  js.Value
+	fnDeleteRenderbuffer             js.Value
+	fnDeleteSampler                  js.Value
+	fnDeleteShader                   js.Value
+	fnDeleteSync                     js.Value
+	fnDeleteTexture                  js.Value
+	fnDeleteVertexArray              js.Value
+	fnDepthFunc                      js.Value
+	fnDepthMask                      js.Value
+	fnDepthRange                     js.Value
+	fnDetachShader                   js.Value
+	fnDisable                        js.Value
+	fnDisableVertexAttribArray       js.Value
+	fnDrawArrays                     js.Value
+	fnDrawArraysInstanced            js.Value
+	fnDrawBuffers                    js.Value
+	fnDrawElements                   js.Value
+	fnDrawElementsInstanced          js.Value
+	fnEnable                         js.Value
+	fnEnableVertexAttribArray        js.Value
+	fnFinish                         js.Value
+	fnFlush                          js.Value
+	fnFramebufferRenderbuffer        js.Value
+	fnFramebufferTexture2D           js.Value
+	fnFramebufferTextureLayer        js.Value
+	fnFrontFace                      js.Value
+	fnFenceSync                      js.Value
+	fnGenerateMipmap                 js.Value
+	fnGetAttachedShaders             js.Value
+	fnGetAttribLocation              js.Value
+	fnGetBufferSubData               js.Value
+	fnGetError                       js.Value
+	fnGetExtension                   js.Value
+	fnGetParameter                   js.Value
+	fnGetProgramInfoLog              js.Value
+	fnGetProgramParameter            js.Value
+	fnGetSamplerParameter            js.Value
+	fnGetShaderInfoLog               js.Value
+	fnGetShaderParameter             js.Value
+	fnGetShaderPrecisionFormat       js.Value
+	fnGetShaderSource                js.Value
+	fnGetSyncParameter               js.Value
+	fnGetUniformBlockIndex           js.Value
+	fnGetUniformLocation             js.Value
+	fnHint                           js.Value
+	fnInvalidateFramebuffer          js.Value
+	fnInvalidateSubFramebuffer       js.Value
+	fnIsBuffer                       js.Value
+	fnIsContextLost                  js.Value
+	fnIsEnabled                      js.Value
+	fnIsFramebuffer                  js.Value
+	fnIsProgram                      js.Value
+	fnIsQuery                        js.Value
+	fnIsRenderbuffer                 js.Value
+	fnIsSampler                      js.Value
+	fnIsShader                       js.Value
+	fnIsSync                         js.Value
+	fnIsTexture                      js.Value
+	fnIsVertexArray                  js.Value
+	fnLineWidth                      js.Value
+	fnLinkProgram                    js.Value
+	fnPixelStorei                    js.Value
+	fnPolygonOffset                  js.Value
+	fnReadBuffer                     js.Value
+	fnReadPixels                     js.Value
+	fnRenderbufferStorage            js.Value
+	fnRenderbufferStorageMultisample js.Value
+	fnSampleCoverage                 js.Value
+	fnSamplerParameterf              js.Value
+	fnSamplerParameteri              js.Value
+	fnScissor                        js.Value
+	fnShaderSource                   js.Value
+	fnStencilFunc                    js.Value
+	fnStencilFuncSeparate            js.Value
+	fnStencilMask                    js.Value
+	fnStencilMaskSeparate            js.Value
+	fnStencilOp                      js.Value
+	fnStencilOpSeparate              js.Value
+	fnTexImage2D                     js.Value
+	fnTexImage3D                     js.Value
+	fnTexParameterf                  js.Value
+	fnTexStorage2D                   js.Value
+	fnTexStorage3D                   js.Value
+	fnTexSubImage2D                  js.Value
+	fnTexSubImage3D                  js.Value
+	fnTexParameteri                  js.Value
+	fnUniform1f                      js.Value
+	fnUniform1i                      js.Value
+	fnUniform2f                      js.Value
+	fnUniform2i                      js.Value
+	fnUniform3f                      js.Value
+	fnUniform3i                      js.Value
+	fnUniform4f                      js.Value
+	fnUniform4i                      js.Value
+	fnUniformBlockBinding            js.Value
+	fnUniformMatrix4fv               js.Value
+	fnUseProgram                     js.Value
+	fnValidateProgram                js.Value
+	fnVertexAttribIPointer           js.Value
+	fnVertexAttribPointer            js.Value
+	fnViewport                       js.Value
+	fnWaitSync                       js.Value
+)
+
+// jsBackend is the Backend implementation that forwards all calls to a
+// WebGL2RenderingContext through syscall/js.
+type jsBackend struct{}
+
+var _ Backend = jsBackend{}
+
+func initFunctions(gl js.Value) {
+	fnActiveTexture = getFunction(gl, "activeTexture")
+	fnAttachShader = getFunction(gl, "attachShader")
+	fnBindBuffer = getFunction(gl, "bindBuffer")
+	fnBindBufferBase = getFunction(gl, "bindBufferBase")
+	fnBindBufferRange = getFunction(gl, "bindBufferRange")
+	fnBindFramebuffer = getFunction(gl, "bindFramebuffer")
+	fnBindRenderbuffer = getFunction(gl, "bindRenderbuffer")
+	fnBindSampler = getFunction(gl, "bindSampler")
+	fnBindTexture = getFunction(gl, "bindTexture")
+	fnBindVertexArray = getFunction(gl, "bindVertexArray")
+	fnBlendColor = getFunction(gl, "blendColor")
+	fnBlendEquation = getFunction(gl, "blendEquation")
+	fnBlendEquationSeparate = getFunction(gl, "blendEquationSeparate")
+	fnBlendFunc = getFunction(gl, "blendFunc")
+	fnBlendFuncSeparate = getFunction(gl, "blendFuncSeparate")
+	fnBlitFramebuffer = getFunction(gl, "blitFramebuffer")
+	fnBufferData = getFunction(gl, "bufferData")
+	fnBufferSubData = getFunction(gl, "bufferSubData")
+	fnCheckFramebufferStatus = getFunction(gl, "checkFramebufferStatus")
+	fnClear = getFunction(gl, "clear")
+	fnClearBufferfv = getFunction(gl, "clearBufferfv")
+	fnClearBufferiv = getFunction(gl, "clearBufferiv")
+	fnClearBufferuiv = getFunction(gl, "clearBufferuiv")
+	fnClearBufferfi = getFunction(gl, "clearBufferfi")
+	fnClearColor = getFunction(gl, "clearColor")
+	fnClearDepth = getFunction(gl, "clearDepth")
+	fnClearStencil = getFunction(gl, "clearStencil")
+	fnClientWaitSync = getFunction(gl, "clientWaitSync")
+	fnColorMask = getFunction(gl, "colorMask")
+	fnCompileShader = getFunction(gl, "compileShader")
+	fnCompressedTexImage2D = getFunction(gl, "compressedTexImage2D")
+	fnCompressedTexImage3D = getFunction(gl, "compressedTexImage3D")
+	fnCompressedTexSubImage2D = getFunction(gl, "compressedTexSubImage2D")
+	fnCompressedTexSubImage3D = getFunction(gl, "compressedTexSubImage3D")
+	fnCopyBufferSubData = getFunction(gl, "copyBufferSubData")
+	fnCopyTexImage2D = getFunction(gl, "copyTexImage2D")
+	fnCopyTexSubImage2D = getFunction(gl, "copyTexSubImage2D")
+	fnCopyTexSubImage3D = getFunction(gl, "copyTexSubImage3D")
+	fnCreateBuffer = getFunction(gl, "createBuffer")
+	fnCreateFramebuffer = getFunction(gl, "createFramebuffer")
+	fnCreateProgram = getFunction(gl, "createProgram")
+	fnCreateRenderbuffer = getFunction(gl, "createRenderbuffer")
+	fnCreateSampler = getFunction(gl, "createSampler")
+	fnCreateShader = getFunction(gl, "createShader")
+	fnCreateTexture = getFunction(gl, "createTexture")
+	fnCreateVertexArray = getFunction(gl, "createVertexArray")
+	fnCullFace = getFunction(gl, "cullFace")
+	fnDeleteBuffer = getFunction(gl, "deleteBuffer")
+	fnDeleteFramebuffer = getFunction(gl, "deleteFramebuffer")
+	fnDeleteProgram = getFunction(gl, "deleteProgram")
+	fnDeleteRenderbuffer = getFunction(gl, "deleteRenderbuffer")
+	fnDeleteSampler = getFunction(gl, "deleteSampler")
+	fnDeleteShader = getFunction(gl, "deleteShader")
+	fnDeleteSync = getFunction(gl, "deleteSync")
+	fnDeleteTexture = getFunction(gl, "deleteTexture")
+	fnDeleteVertexArray = getFunction(gl, "deleteVertexArray")
+	fnDepthFunc = getFunction(gl, "depthFunc")
+	fnDepthMask = getFunction(gl, "depthMask")
+	fnDepthRange = getFunction(gl, "depthRange")
+	fnDetachShader = getFunction(gl, "detachShader")
+	fnDisable = getFunction(gl, "disable")
+	fnDisableVertexAttribArray = getFunction(gl, "disableVertexAttribArray")
+	fnDrawArrays = getFunction(gl, "drawArrays")
+	fnDrawArraysInstanced = getFunction(gl, "drawArraysInstanced")
+	fnDrawBuffers = getFunction(gl, "drawBuffers")
+	fnDrawElements = getFunction(gl, "drawElements")
+	fnDrawElementsInstanced = getFunction(gl, "drawElementsInstanced")
+	fnEnable = getFunction(gl, "enable")
+	fnEnableVertexAttribArray = getFunction(gl, "enableVertexAttribArray")
+	fnFinish = getFunction(gl, "finish")
+	fnFlush = getFunction(gl, "flush")
+	fnFramebufferRenderbuffer = getFunction(gl, "framebufferRenderbuffer")
+	fnFramebufferTexture2D = getFunction(gl, "framebufferTexture2D")
+	fnFramebufferTextureLayer = getFunction(gl, "framebufferTextureLayer")
+	fnFrontFace = getFunction(gl, "frontFace")
+	fnFenceSync = getFunction(gl, "fenceSync")
+	fnGenerateMipmap = getFunction(gl, "generateMipmap")
+	fnGetAttachedShaders = getFunction(gl, "getAttachedShaders")
+	fnGetAttribLocation = getFunction(gl, "getAttribLocation")
+	fnGetBufferSubData = getFunction(gl, "getBufferSubData")
+	fnGetError = getFunction(gl, "getError")
+	fnGetExtension = getFunction(gl, "getExtension")
+	fnGetParameter = getFunction(gl, "getParameter")
+	fnGetProgramInfoLog = getFunction(gl, "getProgramInfoLog")
+	fnGetProgramParameter = getFunction(gl, "getProgramParameter")
+	fnGetSamplerParameter = getFunction(gl, "getSamplerParameter")
+	fnGetShaderInfoLog = getFunction(gl, "getShaderInfoLog")
+	fnGetShaderParameter = getFunction(gl, "getShaderParameter")
+	fnGetShaderPrecisionFormat = getFunction(gl, "getShaderPrecisionFormat")
+	fnGetShaderSource = getFunction(gl, "getShaderSource")
+	fnGetSyncParameter = getFunction(gl, "getSyncParameter")
+	fnGetUniformBlockIndex = getFunction(gl, "getUniformBlockIndex")
+	fnGetUniformLocation = getFunction(gl, "getUniformLocation")
+	fnHint = getFunction(gl, "hint")
+	fnInvalidateFramebuffer = getFunction(gl, "invalidateFramebuffer")
+	fnInvalidateSubFramebuffer = getFunction(gl, "invalidateSubFramebuffer")
+	fnIsBuffer = getFunction(gl, "isBuffer")
+	fnIsContextLost = getFunction(gl, "isContextLost")
+	fnIsEnabled = getFunction(gl, "isEnabled")
+	fnIsFramebuffer = getFunction(gl, "isFramebuffer")
+	fnIsProgram = getFunction(gl, "isProgram")
+	fnIsQuery = getFunction(gl, "isQuery")
+	fnIsRenderbuffer = getFunction(gl, "isRenderbuffer")
+	fnIsSampler = getFunction(gl, "isSampler")
+	fnIsShader = getFunction(gl, "isShader")
+	fnIsSync = getFunction(gl, "isSync")
+	fnIsTexture = getFunction(gl, "isTexture")
+	fnIsVertexArray = getFunction(gl, "isVertexArray")
+	fnLineWidth = getFunction(gl, "lineWidth")
+	fnLinkProgram = getFunction(gl, "linkProgram")
+	fnPixelStorei = getFunction(gl, "pixelStorei")
+	fnPolygonOffset = getFunction(gl, "polygonOffset")
+	fnReadBuffer = getFunction(gl, "readBuffer")
+	fnReadPixels = getFunction(gl, "readPixels")
+	fnRenderbufferStorage = getFunction(gl, "renderbufferStorage")
+	fnRenderbufferStorageMultisample = getFunction(gl, "renderbufferStorageMultisample")
+	fnSampleCoverage = getFunction(gl, "sampleCoverage")
+	fnSamplerParameterf = getFunction(gl, "samplerParameterf")
+	fnSamplerParameteri = getFunction(gl, "samplerParameteri")
+	fnScissor = getFunction(gl, "scissor")
+	fnShaderSource = getFunction(gl, "shaderSource")
+	fnStencilFunc = getFunction(gl, "stencilFunc")
+	fnStencilFuncSeparate = getFunction(gl, "stencilFuncSeparate")
+	fnStencilMask = getFunction(gl, "stencilMask")
+	fnStencilMaskSeparate = getFunction(gl, "stencilMaskSeparate")
+	fnStencilOp = getFunction(gl, "stencilOp")
+	fnStencilOpSeparate = getFunction(gl, "stencilOpSeparate")
+	fnTexImage2D = getFunction(gl, "texImage2D")
+	fnTexImage3D = getFunction(gl, "texImage3D")
+	fnTexParameterf = getFunction(gl, "texParameterf")
+	fnTexStorage2D = getFunction(gl, "texStorage2D")
+	fnTexStorage3D = getFunction(gl, "texStorage3D")
+	fnTexSubImage2D = getFunction(gl, "texSubImage2D")
+	fnTexSubImage3D = getFunction(gl, "texSubImage3D")
+	fnTexParameteri = getFunction(gl, "texParameteri")
+	fnUniform1f = getFunction(gl, "uniform1f")
+	fnUniform1i = getFunction(gl, "uniform1i")
+	fnUniform2f = getFunction(gl, "uniform2f")
+	fnUniform2i = getFunction(gl, "uniform2i")
+	fnUniform3f = getFunction(gl, "uniform3f")
+	fnUniform3i = getFunction(gl, "uniform3i")
+	fnUniform4f = getFunction(gl, "uniform4f")
+	fnUniform4i = getFunction(gl, "uniform4i")
+	fnUniformBlockBinding = getFunction(gl, "uniformBlockBinding")
+	fnUniformMatrix4fv = getFunction(gl, "uniformMatrix4fv")
+	fnUseProgram = getFunction(gl, "useProgram")
+	fnValidateProgram = getFunction(gl, "validateProgram")
+	fnVertexAttribIPointer = getFunction(gl, "vertexAttribIPointer")
+	fnVertexAttribPointer = getFunction(gl, "vertexAttribPointer")
+	fnViewport = getFunction(gl, "viewport")
+	fnWaitSync = getFunction(gl, "waitSync")
+}
+
+func (jsBackend) ActiveTexture(texture GLenum) {
+	fnActiveTexture.Invoke(texture)
+}
+
+func (jsBackend) AttachShader(program Program, shader Shader) {
+	fnAttachShader.Invoke(jsValue(program), jsValue(shader))
+}
+
+func (jsBackend) BindBuffer(target GLenum, buffer Buffer) {
+	fnBindBuffer.Invoke(target, jsValue(buffer))
+}
+
+func (jsBackend) BindBufferBase(target GLenum, index GLuint, buffer Buffer) {
+	fnBindBufferBase.Invoke(target, index, jsValue(buffer))
+}
+
+func (jsBackend) BindBufferRange(target GLenum, index GLuint, buffer Buffer, offset GLintptr, size GLsizeiptr) {
+	fnBindBufferRange.Invoke(target, index, jsValue(buffer), offset, size)
+}
+
+func (jsBackend) BindFramebuffer(target GLenum, framebuffer Framebuffer) {
+	fnBindFramebuffer.Invoke(target, jsValue(framebuffer))
+}
+
+func (jsBackend) BindRenderbuffer(target GLenum, renderbuffer Renderbuffer) {
+	fnBindRenderbuffer.Invoke(target, jsValue(renderbuffer))
+}
+
+func (jsBackend) BindSampler(unit GLuint, sampler Sampler) {
+	fnBindSampler.Invoke(unit, jsValue(sampler))
+}
+
+func (jsBackend) BindTexture(target GLenum, texture Texture) {
+	fnBindTexture.Invoke(target, jsValue(texture))
+}
+
+func (jsBackend) BindVertexArray(array VertexArray) {
+	fnBindVertexArray.Invoke(jsValue(array))
+}
+
+func (jsBackend) BlendColor(red, green, blue, alpha GLclampf) {
+	fnBlendColor.Invoke(red, green, blue, alpha)
+}
+
+func (jsBackend) BlendEquation(mode GLenum) {
+	fnBlendEquation.Invoke(mode)
+}
+
+func (jsBackend) BlendEquationSeparate(modeRGB, modeAlpha GLenum) {
+	fnBlendEquationSeparate.Invoke(modeRGB, modeAlpha)
+}
+
+func (jsBackend) BlendFunc(sfactor, dfactor GLenum) {
+	fnBlendFunc.Invoke(sfactor, dfactor)
+}
+
+func (jsBackend) BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha GLenum) {
+	fnBlendFuncSeparate.Invoke(srcRGB, dstRGB, srcAlpha, dstAlpha)
+}
+
+func (jsBackend) BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1 GLint, mask GLbitfield, filter GLenum) {
+	fnBlitFramebuffer.Invoke(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1, mask, filter)
+}
+
+func (jsBackend) BufferData(target GLenum, size GLsizeiptr, data []byte, usage GLenum) {
+	if data != nil {
+		pushBufferData(data)
+		fnBufferData.Invoke(target, uint8Array, usage, 0, len(data))
+	} else {
+		fnBufferData.Invoke(target, size, usage)
+	}
+}
+
+func (jsBackend) BufferSubData(target GLenum, dstOffset GLintptr, data []byte) {
+	pushBufferData(data)
+	fnBufferSubData.Invoke(target, dstOffset, uint8Array, 0, len(data))
+}
+
+func (jsBackend) CheckFramebufferStatus(target GLenum) GLenum {
+	return GLenum(fnCheckFramebufferStatus.Invoke(target).Int())
+}
+
+func (jsBackend) Clear(mask GLbitfield) {
+	fnClear.Invoke(mask)
+}
+
+func (jsBackend) ClearBufferfv(buffer GLenum, drawBuffer GLint, values Float32List) {
+	pushBufferData(values)
+	fnClearBufferfv.Invoke(buffer, drawBuffer, float32Array)
+}
+
+func (jsBackend) ClearBufferfvOffset(buffer GLenum, drawBuffer GLint, values Float32List, srcOffset GLuint) {
+	pushBufferData(values)
+	fnClearBufferfv.Invoke(buffer, drawBuffer, float32Array, srcOffset)
+}
+
+func (jsBackend) ClearBufferiv(buffer GLenum, drawBuffer GLint, values Int32List) {
+	pushBufferData(values)
+	fnClearBufferiv.Invoke(buffer, drawBuffer, int32Array)
+}
+
+func (jsBackend) ClearBufferivOffset(buffer GLenum, drawBuffer GLint, values Int32List, srcOffset GLuint) {
+	pushBufferData(values)
+	fnClearBufferiv.Invoke(buffer, drawBuffer, int32Array, srcOffset)
+}
+
+func (jsBackend) ClearBufferuiv(buffer GLenum, drawBuffer GLint, values Uint32List) {
+	pushBufferData(values)
+	fnClearBufferuiv.Invoke(buffer, drawBuffer, uint32Array)
+}
+
+func (jsBackend) ClearBufferuivOffset(buffer GLenum, drawBuffer GLint, values Uint32List, srcOffset GLuint) {
+	pushBufferData(values)
+	fnClearBufferuiv.Invoke(buffer, drawBuffer, uint32Array, srcOffset)
+}
+
+func (jsBackend) ClearBufferfi(buffer GLenum, drawBuffer GLint, depth GLfloat, stencil GLint) {
+	fnClearBufferfi.Invoke(buffer, drawBuffer, depth, stencil)
+}
+
+func (jsBackend) ClearColor(r, g, b, a GLclampf) {
+	fnClearColor.Invoke(r, g, b, a)
+}
+
+func (jsBackend) ClearDepth(depth GLclampf) {
+	fnClearDepth.Invoke(depth)
+}
+
+func (jsBackend) ClearStencil(stencil GLint) {
+	fnClearStencil.Invoke(stencil)
+}
+
+func (jsBackend) ClientWaitSync(sync Sync, flags GLbitfield, timeout GLuint64) GLenum {
+	return GLenum(fnClientWaitSync.Invoke(jsValue(sync), flags, timeout).Int())
+}
+
+func (jsBackend) ColorMask(r, g, b, a GLboolean) {
+	fnColorMask.Invoke(r, g, b, a)
+}
+
+func (jsBackend) CompileShader(shader Shader) {
+	fnCompileShader.Invoke(jsValue(shader))
+}
+
+func (jsBackend) CompressedTexImage2D(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, data []byte) {
+	pushBufferData(data)
+	fnCompressedTexImage2D.Invoke(target, level, internalFormat, width, height, border, uint8Array, 0, len(data))
+}
+
+func (jsBackend) CompressedTexImage2DOffset(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, imageSize GLsizei, offset GLintptr) {
+	fnCompressedTexImage2D.Invoke(target, level, internalFormat, width, height, border, imageSize, offset)
+}
+
+func (jsBackend) CompressedTexImage3D(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, data []byte) {
+	pushBufferData(data)
+	fnCompressedTexImage3D.Invoke(target, level, internalFormat, width, height, depth, border, uint8Array, 0, len(data))
+}
+
+func (jsBackend) CompressedTexImage3DOffset(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, imageSize GLsizei, offset GLintptr) {
+	fnCompressedTexImage3D.Invoke(target, level, internalFormat, width, height, depth, border, imageSize, offset)
+}
+
+func (jsBackend) CompressedTexSubImage2D(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format GLenum, data []byte) {
+	pushBufferData(data)
+	fnCompressedTexSubImage2D.Invoke(target, level, xoffset, yoffset, width, height, format, uint8Array, 0, len(data))
+}
+
+func (jsBackend) CompressedTexSubImage2DOffset(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format GLenum, imageSize GLsizei, offset GLintptr) {
+	fnCompressedTexSubImage2D.Invoke(target, level, xoffset, yoffset, width, height, format, imageSize, offset)
+}
+
+func (jsBackend) CompressedTexSubImage3D(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format GLenum, data []byte) {
+	pushBufferData(data)
+	fnCompressedTexSubImage3D.Invoke(target, level, xoffset, yoffset, zoffset, width, height, depth, format, uint8Array, 0, len(data))
+}
+
+func (jsBackend) CompressedTexSubImage3DOffset(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format GLenum, imageSize GLsizei, offset GLintptr) {
+	fnCompressedTexSubImage3D.Invoke(target, level, xoffset, yoffset, zoffset, width, height, depth, format, imageSize, offset)
+}
+
+func (jsBackend) CopyBufferSubData(readTarget, writeTarget GLenum, readOffset, writeOffset GLintptr, size GLsizeiptr) {
+	fnCopyBufferSubData.Invoke(readTarget, writeTarget, readOffset, writeOffset, size)
+}
+
+func (jsBackend) CopyTexImage2D(target GLenum, level GLint, internalFormat GLenum, x, y GLint, width, height GLsizei, border GLint) {
+	fnCopyTexImage2D.Invoke(target, level, internalFormat, x, y, width, height, border)
+}
+
+func (jsBackend) CopyTexSubImage2D(target GLenum, level, xoffset, yoffset, x, y GLint, width, height GLsizei) {
+	fnCopyTexSubImage2D.Invoke(target, level, xoffset, yoffset, x, y, width, height)
+}
+
+func (jsBackend) CopyTexSubImage3D(target GLenum, level, xoffset, yoffset, zoffset, x, y GLint, width, height GLsizei) {
+	fnCopyTexSubImage3D.Invoke(target, level, xoffset, yoffset, zoffset, x, y, width, height)
+}
+
+func (jsBackend) CreateBuffer() Buffer {
+	return NewBuffer(fnCreateBuffer.Invoke())
+}
+
+func (jsBackend) CreateFramebuffer() Framebuffer {
+	return NewFramebuffer(fnCreateFramebuffer.Invoke())
+}
+
+func (jsBackend) CreateProgram() Program {
+	return NewProgram(fnCreateProgram.Invoke())
+}
+
+func (jsBackend) CreateRenderbuffer() Renderbuffer {
+	return NewRenderbuffer(fnCreateRenderbuffer.Invoke())
+}
+
+func (jsBackend) CreateSampler() Sampler {
+	return NewSampler(fnCreateSampler.Invoke())
+}
+
+func (jsBackend) CreateShader(shaderType GLenum) Shader {
+	return NewShader(fnCreateShader.Invoke(shaderType))
+}
+
+func (jsBackend) CreateTexture() Texture {
+	return NewTexture(fnCreateTexture.Invoke())
+}
+
+func (jsBackend) CreateVertexArray() VertexArray {
+	return NewVertexArray(fnCreateVertexArray.Invoke())
+}
+
+func (jsBackend) CullFace(mode GLenum) {
+	fnCullFace.Invoke(mode)
+}
+
+func (jsBackend) DeleteBuffer(buffer Buffer) {
+	fnDeleteBuffer.Invoke(jsValue(buffer))
+}
+
+func (jsBackend) DeleteFramebuffer(framebuffer Framebuffer) {
+	fnDeleteFramebuffer.Invoke(jsValue(framebuffer))
+}
+
+func (jsBackend) DeleteProgram(program Program) {
+	fnDeleteProgram.Invoke(jsValue(program))
+}
+
+func (jsBackend) DeleteRenderbuffer(renderbuffer Renderbuffer) {
+	fnDeleteRenderbuffer.Invoke(jsValue(renderbuffer))
+}
+
+func (jsBackend) DeleteSampler(sampler Sampler) {
+	fnDeleteSampler.Invoke(jsValue(sampler))
+}
+
+func (jsBackend) DeleteShader(shader Shader) {
+	fnDeleteShader.Invoke(jsValue(shader))
+}
+
+func (jsBackend) DeleteSync(sync Sync) {
+	fnDeleteSync.Invoke(jsValue(sync))
+}
+
+func (jsBackend) DeleteTexture(texture Texture) {
+	fnDeleteTexture.Invoke(jsValue(texture))
+}
+
+func (jsBackend) DeleteVertexArray(array VertexArray) {
+	fnDeleteVertexArray.Invoke(jsValue(array))
+}
+
+func (jsBackend) DepthFunc(fn GLenum) {
+	fnDepthFunc.Invoke(fn)
+}
+
+func (jsBackend) DepthMask(mask GLboolean) {
+	fnDepthMask.Invoke(mask)
+}
+
+func (jsBackend) DepthRange(zNear, zFar GLclampf) {
+	fnDepthRange.Invoke(zNear, zFar)
+}
+
+func (jsBackend) DetachShader(program Program, shader Shader) {
+	fnDetachShader.Invoke(jsValue(program), jsValue(shader))
+}
+
+func (jsBackend) Disable(cap GLenum) {
+	fnDisable.Invoke(cap)
+}
+
+func (jsBackend) DisableVertexAttribArray(index GLuint) {
+	fnDisableVertexAttribArray.Invoke(index)
+}
+
+func (jsBackend) DrawArrays(mode GLenum, first GLint, count GLsizei) {
+	fnDrawArrays.Invoke(mode, first, count)
+}
+
+func (jsBackend) DrawArraysInstanced(mode GLenum, first GLint, count, instanceCount GLsizei) {
+	fnDrawArraysInstanced.Invoke(mode, first, count, instanceCount)
+}
+
+func (jsBackend) DrawBuffers(buffers []GLenum) {
+	fnDrawBuffers.Invoke(pushEnumData(buffers))
+}
+
+func (jsBackend) DrawElements(mode GLenum, count GLsizei, dtype GLenum, offset GLintptr) {
+	fnDrawElements.Invoke(mode, count, dtype, offset)
+}
+
+func (jsBackend) DrawElementsInstanced(mode GLenum, count GLsizei, pType GLenum, offset GLintptr, instanceCount GLsizei) {
+	fnDrawElementsInstanced.Invoke(mode, count, pType, offset, instanceCount)
+}
+
+func (jsBackend) DrawingBufferColorSpace() ColorSpace {
+	return ColorSpace(stringOr(context.Get("drawingBufferColorSpace"), string(ColorSpaceSRGB)))
+}
+
+func (jsBackend) DrawingBufferFormat() GLenum {
+	format := context.Get("drawingBufferFormat")
+	if format.Type() != js.TypeNumber {
+		return RGBA8
+	}
+	return GLenum(format.Int())
+}
+
+func (jsBackend) DrawingBufferHeight() int {
+	return context.Get("drawingBufferHeight").Int()
+}
+
+func (jsBackend) DrawingBufferStorage(internalFormat GLenum, width, height GLsizei) {
+	context.Call("drawingBufferStorage", internalFormat, width, height)
+}
+
+func (jsBackend) DrawingBufferWidth() int {
+	return context.Get("drawingBufferWidth").Int()
+}
+
+func (jsBackend) Enable(cap GLenum) {
+	fnEnable.Invoke(cap)
+}
+
+func (jsBackend) EnableVertexAttribArray(index GLuint) {
+	fnEnableVertexAttribArray.Invoke(index)
+}
+
+func (jsBackend) Finish() {
+	fnFinish.Invoke()
+}
+
+func (jsBackend) Flush() {
+	fnFlush.Invoke()
+}
+
+func (jsBackend) FramebufferRenderbuffer(target, attachment, renderbufferTarget GLenum, renderbuffer Renderbuffer) {
+	fnFramebufferRenderbuffer.Invoke(target, attachment, renderbufferTarget, jsValue(renderbuffer))
+}
+
+func (jsBackend) FramebufferTexture2D(target, attachment, texTarget GLenum, texture Texture, level GLint) {
+	fnFramebufferTexture2D.Invoke(target, attachment, texTarget, jsValue(texture), level)
+}
+
+func (jsBackend) FramebufferTextureLayer(target, attachment GLenum, texture Texture, level, layer GLint) {
+	fnFramebufferTextureLayer.Invoke(target, attachment, jsValue(texture), level, layer)
+}
+
+func (jsBackend) FrontFace(mode GLenum) {
+	fnFrontFace.Invoke(mode)
+}
+
+func (jsBackend) FenceSync(condition GLenum, flags GLbitfield) Sync {
+	return NewSync(fnFenceSync.Invoke(condition, flags))
+}
+
+func (jsBackend) GenerateMipmap(target GLenum) {
+	fnGenerateMipmap.Invoke(target)
+}
+
+func (jsBackend) GetAttachedShaders(program Program) []Shader {
+	result := fnGetAttachedShaders.Invoke(jsValue(program))
+	if result.IsNull() {
+		return nil
+	}
+	shaders := make([]Shader, result.Length())
+	for i := range shaders {
+		shaders[i] = NewShader(result.Index(i))
+	}
+	return shaders
+}
+
+func (jsBackend) GetAttribLocation(program Program, name string) GLint {
+	return GLint(fnGetAttribLocation.Invoke(jsValue(program), name).Int())
+}
+
+func (jsBackend) GetBufferSubData(target GLenum, srcOffset GLintptr, data []byte) {
+	length := len(data)
+	ensureBufferSize(length)
+	fnGetBufferSubData.Invoke(target, srcOffset, uint8Array, 0, length)
+	popBufferData(data)
+}
+
+func (jsBackend) GetError() GLenum {
+	return GLenum(fnGetError.Invoke().Int())
+}
+
+func (jsBackend) GetExtension(name string) any {
+	result := fnGetExtension.Invoke(name)
+	if result.IsNull() {
+		return nil
+	}
+	// We return plain true at this point in time but in the future it might
+	// be possible to return a specific extension struct here. This is why
+	// the result type has been left as any.
+	return true
+}
+
+func (jsBackend) GetParameter(name GLenum) Any {
+	return NewAny(fnGetParameter.Invoke(name))
+}
+
+func (jsBackend) GetProgramInfoLog(program Program) string {
+	return fnGetProgramInfoLog.Invoke(jsValue(program)).String()
+}
+
+func (jsBackend) GetProgramParameter(program Program, pname GLenum) Any {
+	return NewAny(fnGetProgramParameter.Invoke(jsValue(program), pname))
+}
+
+func (jsBackend) GetSamplerParameter(sampler Sampler, pname GLenum) Any {
+	return NewAny(fnGetSamplerParameter.Invoke(jsValue(sampler), pname))
+}
+
+func (jsBackend) GetShaderInfoLog(shader Shader) string {
+	return fnGetShaderInfoLog.Invoke(jsValue(shader)).String()
+}
+
+func (jsBackend) GetShaderParameter(shader Shader, pname GLenum) Any {
+	return NewAny(fnGetShaderParameter.Invoke(jsValue(shader), pname))
+}
+
+func (jsBackend) GetShaderPrecisionFormat(shaderType, precisionType GLenum) ShaderPrecisionFormat {
+	result := fnGetShaderPrecisionFormat.Invoke(shaderType, precisionType)
+	if result.IsNull() {
+		return ShaderPrecisionFormat{}
+	}
+	return ShaderPrecisionFormat{
+		RangeMin:  GLint(result.Get("rangeMin").Int()),
+		RangeMax:  GLint(result.Get("rangeMax").Int()),
+		Precision: GLint(result.Get("precision").Int()),
+	}
+}
+
+func (jsBackend) GetShaderSource(shader Shader) string {
+	result := fnGetShaderSource.Invoke(jsValue(shader))
+	if result.IsNull() {
+		return ""
+	}
+	return result.String()
+}
+
+func (jsBackend) GetSyncParameter(sync Sync, pname GLenum) Any {
+	return NewAny(fnGetSyncParameter.Invoke(jsValue(sync), pname))
+}
+
+func (jsBackend) GetUniformBlockIndex(program Program, name string) GLuint {
+	return GLuint(fnGetUniformBlockIndex.Invoke(jsValue(program), name).Int())
+}
+
+func (jsBackend) GetUniformLocation(program Program, name string) UniformLocation {
+	return NewUniformLocation(fnGetUniformLocation.Invoke(jsValue(program), name))
+}
+
+func (jsBackend) Hint(target, mode GLenum) {
+	fnHint.Invoke(target, mode)
+}
+
+func (jsBackend) InvalidateFramebuffer(target GLenum, attachments []GLenum) {
+	fnInvalidateFramebuffer.Invoke(target, pushEnumData(attachments))
+}
+
+func (jsBackend) InvalidateSubFramebuffer(target GLenum, attachments []GLenum, x, y GLint, width, height GLsizei) {
+	fnInvalidateSubFramebuffer.Invoke(target, pushEnumData(attachments), x, y, width, height)
+}
+
+func (jsBackend) IsBuffer(buffer Buffer) bool {
+	return fnIsBuffer.Invoke(jsValue(buffer)).Bool()
+}
+
+func (jsBackend) IsContextLost() bool {
+	return fnIsContextLost.Invoke().Bool()
+}
+
+func (jsBackend) IsEnabled(cap GLenum) bool {
+	return fnIsEnabled.Invoke(cap).Bool()
+}
+
+func (jsBackend) IsFramebuffer(framebuffer Framebuffer) bool {
+	return fnIsFramebuffer.Invoke(jsValue(framebuffer)).Bool()
+}
+
+func (jsBackend) IsProgram(program Program) bool {
+	return fnIsProgram.Invoke(jsValue(program)).Bool()
+}
+
+func (jsBackend) IsQuery(query Query) bool {
+	return fnIsQuery.Invoke(jsValue(query)).Bool()
+}
+
+func (jsBackend) IsRenderbuffer(renderbuffer Renderbuffer) bool {
+	return fnIsRenderbuffer.Invoke(jsValue(renderbuffer)).Bool()
+}
+
+func (jsBackend) IsSampler(sampler Sampler) bool {
+	return fnIsSampler.Invoke(jsValue(sampler)).Bool()
+}
+
+func (jsBackend) IsShader(shader Shader) bool {
+	return fnIsShader.Invoke(jsValue(shader)).Bool()
+}
+
+func (jsBackend) IsSync(sync Sync) bool {
+	return fnIsSync.Invoke(jsValue(sync)).Bool()
+}
+
+func (jsBackend) IsTexture(texture Texture) bool {
+	return fnIsTexture.Invoke(jsValue(texture)).Bool()
+}
+
+func (jsBackend) IsVertexArray(array VertexArray) bool {
+	return fnIsVertexArray.Invoke(jsValue(array)).Bool()
+}
+
+func (jsBackend) LineWidth(width GLfloat) {
+	fnLineWidth.Invoke(width)
+}
+
+func (jsBackend) LinkProgram(program Program) {
+	fnLinkProgram.Invoke(jsValue(program))
+}
+
+func (jsBackend) PixelStorei(pname GLenum, param GLint) {
+	fnPixelStorei.Invoke(pname, param)
+}
+
+func (jsBackend) PolygonOffset(factor, units GLfloat) {
+	fnPolygonOffset.Invoke(factor, units)
+}
+
+func (jsBackend) ReadBuffer(src GLenum) {
+	fnReadBuffer.Invoke(src)
+}
+
+func (jsBackend) ReadPixels(x, y GLint, width, height GLsizei, format, dtype GLenum, offset GLintptr) {
+	fnReadPixels.Invoke(x, y, width, height, format, dtype, offset)
+}
+
+func (jsBackend) ReadPixelsData(x, y GLint, width, height GLsizei, format, dtype GLenum, data []byte) {
+	ensureBufferSize((len(data) + 3) &^ 3)
+	fnReadPixels.Invoke(x, y, width, height, format, dtype, pixelView(dtype), 0)
+	popBufferData(data)
+}
+
+func (jsBackend) RenderbufferStorage(target, internalFormat GLenum, width, height GLsizei) {
+	fnRenderbufferStorage.Invoke(target, internalFormat, width, height)
+}
+
+func (jsBackend) RenderbufferStorageMultisample(target GLenum, samples GLsizei, internalFormat GLenum, width, height GLsizei) {
+	fnRenderbufferStorageMultisample.Invoke(target, samples, internalFormat, width, height)
+}
+
+func (jsBackend) SampleCoverage(value GLclampf, invert GLboolean) {
+	fnSampleCoverage.Invoke(value, invert)
+}
+
+func (jsBackend) SamplerParameterf(sampler Sampler, pname GLenum, param GLfloat) {
+	fnSamplerParameterf.Invoke(jsValue(sampler), pname, param)
+}
+
+func (jsBackend) SamplerParameteri(sampler Sampler, pname GLenum, param GLint) {
+	fnSamplerParameteri.Invoke(jsValue(sampler), pname, param)
+}
+
+func (jsBackend) Scissor(x, y GLint, width, height GLsizei) {
+	fnScissor.Invoke(x, y, width, height)
+}
+
+func (jsBackend) SetDrawingBufferColorSpace(colorSpace ColorSpace) {
+	context.Set("drawingBufferColorSpace", string(colorSpace))
+}
+
+func (jsBackend) SetUnpackColorSpace(colorSpace ColorSpace) {
+	context.Set("unpackColorSpace", string(colorSpace))
+}
+
+func (jsBackend) ShaderSource(shader Shader, source string) {
+	fnShaderSource.Invoke(jsValue(shader), source)
+}
+
+func (jsBackend) StencilFunc(fun GLenum, ref GLint, mask GLuint) {
+	fnStencilFunc.Invoke(fun, ref, mask)
+}
+
+func (jsBackend) StencilFuncSeparate(face, fun GLenum, ref GLint, mask GLuint) {
+	fnStencilFuncSeparate.Invoke(face, fun, ref, mask)
+}
+
+func (jsBackend) StencilMask(mask GLuint) {
+	fnStencilMask.Invoke(mask)
+}
+
+func (jsBackend) StencilMaskSeparate(face GLenum, mask GLuint) {
+	fnStencilMaskSeparate.Invoke(face, mask)
+}
+
+func (jsBackend) StencilOp(fail, zfail, zpass GLenum) {
+	fnStencilOp.Invoke(fail, zfail, zpass)
+}
+
+func (jsBackend) StencilOpSeparate(face, fail, zfail, zpass GLenum) {
+	fnStencilOpSeparate.Invoke(face, fail, zfail, zpass)
+}
+
+func (jsBackend) TexImage2D(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, data []byte) {
+	pushBufferData(data)
+	fnTexImage2D.Invoke(target, level, internalFormat, width, height, border, format, dtype, uint8Array, 0)
+}
+
+func (jsBackend) TexImage2DOffset(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, offset GLintptr) {
+	fnTexImage2D.Invoke(target, level, internalFormat, width, height, border, format, dtype, offset)
+}
+
+func (jsBackend) TexImage3D(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, data []byte) {
+	pushBufferData(data)
+	fnTexImage3D.Invoke(target, level, internalFormat, width, height, depth, border, format, dtype, uint8Array, 0)
+}
+
+func (jsBackend) TexImage3DOffset(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, offset GLintptr) {
+	fnTexImage3D.Invoke(target, level, internalFormat, width, height, depth, border, format, dtype, offset)
+}
+
+func (jsBackend) TexParameterf(target, pname GLenum, param GLfloat) {
+	fnTexParameterf.Invoke(target, pname, param)
+}
+
+func (jsBackend) TexStorage2D(target GLenum, levels GLsizei, internalFormat GLenum, width, height GLsizei) {
+	fnTexStorage2D.Invoke(target, levels, internalFormat, width, height)
+}
+
+func (jsBackend) TexStorage3D(target GLenum, levels GLsizei, internalFormat GLenum, width, height, depth GLsizei) {
+	fnTexStorage3D.Invoke(target, levels, internalFormat, width, height, depth)
+}
+
+func (jsBackend) TexSubImage2D(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format, dtype GLenum, data []byte) {
+	pushBufferData(data)
+	switch dtype {
+	case UNSIGNED_BYTE:
+		fnTexSubImage2D.Invoke(target, level, xoffset, yoffset, width, height, format, dtype, uint8Array, 0)
+	case HALF_FLOAT:
+		fnTexSubImage2D.Invoke(target, level, xoffset, yoffset, width, height, format, dtype, uint16Array, 0)
+	case FLOAT:
+		fnTexSubImage2D.Invoke(target, level, xoffset, yoffset, width, height, format, dtype, float32Array, 0)
+	default:
+		panic(fmt.Errorf("unsupported dtype: %d", dtype))
+	}
+}
+
+func (jsBackend) TexSubImage2DOffset(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format, dtype GLenum, offset GLintptr) {
+	fnTexSubImage2D.Invoke(target, level, xoffset, yoffset, width, height, format, dtype, offset)
+}
+
+func (jsBackend) TexSubImage3D(target GLenum, level GLint, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format, dtype GLenum, data []byte) {
+	pushBufferData(data)
+	fnTexSubImage3D.Invoke(target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, uint8Array, 0)
+}
+
+func (jsBackend) TexParameteri(target, pname GLenum, param GLint) {
+	fnTexParameteri.Invoke(target, pname, param)
+}
+
+func (jsBackend) TexSubImage3DOffset(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format, dtype GLenum, offset GLintptr) {
+	fnTexSubImage3D.Invoke(target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, offset)
+}
+
+func (jsBackend) Uniform1f(location UniformLocation, x GLfloat) {
+	fnUniform1f.Invoke(jsValue(location), x)
+}
+
+func (jsBackend) Uniform1i(location UniformLocation, x GLint) {
+	fnUniform1i.Invoke(jsValue(location), x)
+}
+
+func (jsBackend) Uniform2f(location UniformLocation, x, y GLfloat) {
+	fnUniform2f.Invoke(jsValue(location), x, y)
+}
+
+func (jsBackend) Uniform2i(location UniformLocation, x, y GLint) {
+	fnUniform2i.Invoke(jsValue(location), x, y)
+}
+
+func (jsBackend) Uniform3f(location UniformLocation, x, y, z GLfloat) {
+	fnUniform3f.Invoke(jsValue(location), x, y, z)
+}
+
+func (jsBackend) Uniform3i(location UniformLocation, x, y, z GLint) {
+	fnUniform3i.Invoke(jsValue(location), x, y, z)
+}
+
+func (jsBackend) Uniform4f(location UniformLocation, x, y, z, w GLfloat) {
+	fnUniform4f.Invoke(jsValue(location), x, y, z, w)
+}
+
+func (jsBackend) Uniform4i(location UniformLocation, x, y, z, w GLint) {
+	fnUniform4i.Invoke(jsValue(location), x, y, z, w)
+}
+
+func (jsBackend) UniformBlockBinding(program Program, index, binding GLuint) {
+	fnUniformBlockBinding.Invoke(jsValue(program), index, binding)
+}
+
+func (jsBackend) UniformMatrix4fv(location UniformLocation, transpose GLboolean, data []GLfloat) {
+	pushBufferData(data)
+	fnUniformMatrix4fv.Invoke(jsValue(location), transpose, float32Array, 0, len(data))
+}
+
+func (jsBackend) UnpackColorSpace() ColorSpace {
+	return ColorSpace(stringOr(context.Get("unpackColorSpace"), string(ColorSpaceSRGB)))
+}
+
+func (jsBackend) UseProgram(program Program) {
+	fnUseProgram.Invoke(jsValue(program))
+}
+
+func (jsBackend) ValidateProgram(program Program) {
+	fnValidateProgram.Invoke(jsValue(program))
+}
+
+func (jsBackend) VertexAttribIPointer(index GLuint, size GLint, dtype GLenum, stride GLsizei, offset GLintptr) {
+	fnVertexAttribIPointer.Invoke(index, size, dtype, stride, offset)
+}
+
+func (jsBackend) VertexAttribPointer(index GLuint, size GLint, dtype GLenum, normalized GLboolean, stride GLsizei, offset GLintptr) {
+	fnVertexAttribPointer.Invoke(index, size, dtype, normalized, stride, offset)
+}
+
+func (jsBackend) Viewport(x, y GLint, width, height GLsizei) {
+	fnViewport.Invoke(x, y, width, height)
+}
+
+func (jsBackend) WaitSync(sync Sync, flags GLbitfield, timeout GLint64) {
+	fnWaitSync.Invoke(jsValue(sync), flags, timeout)
+}
+
+// jsValue returns the js.Value that is wrapped by the specified object
+// type. The null value is returned for unspecified objects.
+func jsValue(v interface{ Value() any }) js.Value {
+	if value, ok := v.Value().(js.Value); ok {
+		return value
+	}
+	return js.Null()
+}
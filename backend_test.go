@@ -0,0 +1,18 @@
+package wasmgl_test
+
+import (
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+)
+
+// useBackend configures the specified Backend for the package-level
+// functions until the end of the test.
+func useBackend(t testing.TB, b wasmgl.Backend) {
+	t.Helper()
+	previous := wasmgl.CurrentBackend()
+	wasmgl.SetBackend(b)
+	t.Cleanup(func() {
+		wasmgl.SetBackend(previous)
+	})
+}
@@ -0,0 +1,257 @@
+package wasmgl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// The checked functions in this file are variants of the respective WebGL
+// functions that report failures as errors. Errors that were caused by
+// earlier calls and not checked yet are returned as a *PendingError, joined
+// with the errors of the call itself, so that failures are not attributed to
+// the wrong call.
+//
+// Context loss is reported as ErrContextLost and failed allocations as
+// ErrOutOfMemory (use errors.Is to check). JavaScript exceptions that are
+// thrown by the call are returned as errors as well, instead of panicking.
+
+// CreateBufferChecked is like CreateBuffer but returns an error if the
+// Buffer could not be created.
+func CreateBufferChecked() (Buffer, error) {
+	return checkCreate("CreateBuffer", CreateBuffer)
+}
+
+// CreateFramebufferChecked is like CreateFramebuffer but returns an error
+// if the Framebuffer could not be created.
+func CreateFramebufferChecked() (Framebuffer, error) {
+	return checkCreate("CreateFramebuffer", CreateFramebuffer)
+}
+
+// CreateProgramChecked is like CreateProgram but returns an error if the
+// Program could not be created.
+func CreateProgramChecked() (Program, error) {
+	return checkCreate("CreateProgram", CreateProgram)
+}
+
+// CreateRenderbufferChecked is like CreateRenderbuffer but returns an error
+// if the Renderbuffer could not be created.
+func CreateRenderbufferChecked() (Renderbuffer, error) {
+	return checkCreate("CreateRenderbuffer", CreateRenderbuffer)
+}
+
+// CreateSamplerChecked is like CreateSampler but returns an error if the
+// Sampler could not be created.
+func CreateSamplerChecked() (Sampler, error) {
+	return checkCreate("CreateSampler", CreateSampler)
+}
+
+// CreateShaderChecked is like CreateShader but returns an error if the
+// Shader could not be created.
+func CreateShaderChecked(shaderType GLenum) (Shader, error) {
+	return checkCreate("CreateShader", func() Shader {
+		return CreateShader(shaderType)
+	})
+}
+
+// CreateTextureChecked is like CreateTexture but returns an error if the
+// Texture could not be created.
+func CreateTextureChecked() (Texture, error) {
+	return checkCreate("CreateTexture", CreateTexture)
+}
+
+// CreateVertexArrayChecked is like CreateVertexArray but returns an error
+// if the VertexArray could not be created.
+func CreateVertexArrayChecked() (VertexArray, error) {
+	return checkCreate("CreateVertexArray", CreateVertexArray)
+}
+
+// FenceSyncChecked is like FenceSync but returns an error if the Sync could
+// not be created.
+func FenceSyncChecked(condition GLenum, flags GLbitfield) (Sync, error) {
+	return checkCreate("FenceSync", func() Sync {
+		return FenceSync(condition, flags)
+	})
+}
+
+// BufferDataChecked is like BufferData but returns an error if the storage
+// could not be allocated.
+func BufferDataChecked(target GLenum, size GLsizeiptr, data []byte, usage GLenum) error {
+	return checkCall("BufferData", func() {
+		BufferData(target, size, data, usage)
+	})
+}
+
+// RenderbufferStorageChecked is like RenderbufferStorage but returns an
+// error if the storage could not be allocated.
+func RenderbufferStorageChecked(target, internalFormat GLenum, width, height GLsizei) error {
+	return checkCall("RenderbufferStorage", func() {
+		RenderbufferStorage(target, internalFormat, width, height)
+	})
+}
+
+// RenderbufferStorageMultisampleChecked is like
+// RenderbufferStorageMultisample but returns an error if the storage could
+// not be allocated.
+func RenderbufferStorageMultisampleChecked(target GLenum, samples GLsizei, internalFormat GLenum, width, height GLsizei) error {
+	return checkCall("RenderbufferStorageMultisample", func() {
+		RenderbufferStorageMultisample(target, samples, internalFormat, width, height)
+	})
+}
+
+// TexStorage2DChecked is like TexStorage2D but returns an error if the
+// storage could not be allocated.
+func TexStorage2DChecked(target GLenum, levels GLsizei, internalFormat GLenum, width, height GLsizei) error {
+	return checkCall("TexStorage2D", func() {
+		TexStorage2D(target, levels, internalFormat, width, height)
+	})
+}
+
+// TexStorage3DChecked is like TexStorage3D but returns an error if the
+// storage could not be allocated.
+func TexStorage3DChecked(target GLenum, levels GLsizei, internalFormat GLenum, width, height, depth GLsizei) error {
+	return checkCall("TexStorage3D", func() {
+		TexStorage3D(target, levels, internalFormat, width, height, depth)
+	})
+}
+
+// BufferSubDataChecked is like BufferSubData but returns an error if the
+// data could not be written.
+func BufferSubDataChecked(target GLenum, dstOffset GLintptr, data []byte) error {
+	return checkCall("BufferSubData", func() {
+		BufferSubData(target, dstOffset, data)
+	})
+}
+
+// GetBufferSubDataChecked is like GetBufferSubData but returns an error if
+// the data could not be read.
+func GetBufferSubDataChecked[T DataTypes](target GLenum, srcOffset GLintptr, data []T) error {
+	return checkCall("GetBufferSubData", func() {
+		GetBufferSubData(target, srcOffset, data)
+	})
+}
+
+// TexImage2DChecked is like TexImage2D but returns an error if the image
+// could not be specified.
+func TexImage2DChecked(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, data []byte) error {
+	return checkCall("TexImage2D", func() {
+		TexImage2D(target, level, internalFormat, width, height, border, format, dtype, data)
+	})
+}
+
+// TexImage3DChecked is like TexImage3D but returns an error if the image
+// could not be specified.
+func TexImage3DChecked(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, data []byte) error {
+	return checkCall("TexImage3D", func() {
+		TexImage3D(target, level, internalFormat, width, height, depth, border, format, dtype, data)
+	})
+}
+
+// TexSubImage2DChecked is like TexSubImage2D but returns an error if the
+// image could not be updated.
+func TexSubImage2DChecked(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format, dtype GLenum, data []byte) error {
+	return checkCall("TexSubImage2D", func() {
+		TexSubImage2D(target, level, xoffset, yoffset, width, height, format, dtype, data)
+	})
+}
+
+// TexSubImage3DChecked is like TexSubImage3D but returns an error if the
+// image could not be updated.
+func TexSubImage3DChecked(target GLenum, level GLint, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format, dtype GLenum, data []byte) error {
+	return checkCall("TexSubImage3D", func() {
+		TexSubImage3D(target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, data)
+	})
+}
+
+// CompressedTexImage2DChecked is like CompressedTexImage2D but returns an
+// error if the image could not be specified.
+func CompressedTexImage2DChecked(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, data []byte) error {
+	return checkCall("CompressedTexImage2D", func() {
+		CompressedTexImage2D(target, level, internalFormat, width, height, border, data)
+	})
+}
+
+// CompressedTexImage3DChecked is like CompressedTexImage3D but returns an
+// error if the image could not be specified.
+func CompressedTexImage3DChecked(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, data []byte) error {
+	return checkCall("CompressedTexImage3D", func() {
+		CompressedTexImage3D(target, level, internalFormat, width, height, depth, border, data)
+	})
+}
+
+// CompressedTexSubImage2DChecked is like CompressedTexSubImage2D but returns
+// an error if the image could not be updated.
+func CompressedTexSubImage2DChecked(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format GLenum, data []byte) error {
+	return checkCall("CompressedTexSubImage2D", func() {
+		CompressedTexSubImage2D(target, level, xoffset, yoffset, width, height, format, data)
+	})
+}
+
+// CompressedTexSubImage3DChecked is like CompressedTexSubImage3D but returns
+// an error if the image could not be updated.
+func CompressedTexSubImage3DChecked(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format GLenum, data []byte) error {
+	return checkCall("CompressedTexSubImage3D", func() {
+		CompressedTexSubImage3D(target, level, xoffset, yoffset, zoffset, width, height, depth, format, data)
+	})
+}
+
+// ReadPixelsDataChecked is like ReadPixelsData but returns an error if the
+// pixels could not be read.
+func ReadPixelsDataChecked[T DataTypes](x, y GLint, width, height GLsizei, format, dtype GLenum, data []T) error {
+	return checkCall("ReadPixelsData", func() {
+		ReadPixelsData(x, y, width, height, format, dtype, data)
+	})
+}
+
+// checkCreate calls the specified create function and returns an error if
+// it did not produce a valid handle. A valid handle is returned together
+// with a *PendingError if there were errors pending before the call.
+func checkCreate[T interface{ IsValid() bool }](function string, create func() T) (T, error) {
+	var result T
+	err := checkCall(function, func() {
+		result = create()
+	})
+	switch {
+	case err != nil:
+		return result, err
+	case result.IsValid():
+		return result, nil
+	case IsContextLost():
+		return result, ErrContextLost
+	default:
+		return result, fmt.Errorf("%s returned a nil handle", function)
+	}
+}
+
+// checkCall calls the specified function and returns any JavaScript
+// exception that it throws or, if there is none, the errors that the call
+// caused. Errors that were pending before the call are returned as a
+// *PendingError in addition.
+func checkCall(function string, call func()) error {
+	pending := CheckError()
+	err := callError(function, call)
+	switch {
+	case pending == nil:
+		return err
+	case err == nil:
+		return &PendingError{Function: function, Err: pending}
+	default:
+		return errors.Join(&PendingError{Function: function, Err: pending}, err)
+	}
+}
+
+// callError calls the specified function and returns any JavaScript
+// exception that it throws as an error or, if there is none, the errors
+// that are reported by CheckError.
+func callError(function string, call func()) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			exception, ok := asException(recovered)
+			if !ok {
+				panic(recovered)
+			}
+			err = fmt.Errorf("%s threw an exception: %w", function, exception)
+		}
+	}()
+	call()
+	return CheckError()
+}
@@ -0,0 +1,253 @@
+package wasmgl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+	"github.com/mokiat/wasmgl/wasmgltest"
+)
+
+func TestCheckedCreate(t *testing.T) {
+	testCases := []struct {
+		name        string
+		prepare     func(b *wasmgltest.Backend)
+		create      func() (interface{ IsValid() bool }, error)
+		wantPending error
+		wantErr     error
+	}{
+		{
+			name:    "buffer",
+			prepare: func(b *wasmgltest.Backend) {},
+			create: func() (interface{ IsValid() bool }, error) {
+				return wasmgl.CreateBufferChecked()
+			},
+		},
+		{
+			name: "texture after earlier error",
+			prepare: func(b *wasmgltest.Backend) {
+				b.Enable(wasmgl.TEXTURE_2D)
+			},
+			create: func() (interface{ IsValid() bool }, error) {
+				return wasmgl.CreateTextureChecked()
+			},
+			wantPending: wasmgl.ErrInvalidEnum,
+		},
+		{
+			name: "shader after context loss",
+			prepare: func(b *wasmgltest.Backend) {
+				b.LoseContext()
+			},
+			create: func() (interface{ IsValid() bool }, error) {
+				return wasmgl.CreateShaderChecked(wasmgl.VERTEX_SHADER)
+			},
+			wantPending: wasmgl.ErrContextLost,
+			wantErr:     wasmgl.ErrContextLost,
+		},
+		{
+			name:    "fence with invalid condition",
+			prepare: func(b *wasmgltest.Backend) {},
+			create: func() (interface{ IsValid() bool }, error) {
+				return wasmgl.FenceSyncChecked(wasmgl.SIGNALED, 0)
+			},
+			wantErr: wasmgl.ErrInvalidEnum,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			useBackend(t, b)
+			tc.prepare(b)
+
+			handle, err := tc.create()
+			checkErrors(t, err, tc.wantPending, tc.wantErr)
+			if got, want := handle.IsValid(), tc.wantErr == nil; got != want {
+				t.Errorf("IsValid() = %t, want %t", got, want)
+			}
+		})
+	}
+}
+
+func TestCheckedCall(t *testing.T) {
+	testCases := []struct {
+		name        string
+		prepare     func(b *wasmgltest.Backend)
+		wantPending error
+		wantErr     error
+	}{
+		{
+			name: "success",
+			prepare: func(b *wasmgltest.Backend) {
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, b.CreateBuffer())
+			},
+		},
+		{
+			name: "earlier error",
+			prepare: func(b *wasmgltest.Backend) {
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, b.CreateBuffer())
+				b.Enable(wasmgl.TEXTURE_2D)
+			},
+			wantPending: wasmgl.ErrInvalidEnum,
+		},
+		{
+			name: "earlier error and no bound buffer",
+			prepare: func(b *wasmgltest.Backend) {
+				b.Enable(wasmgl.TEXTURE_2D)
+			},
+			wantPending: wasmgl.ErrInvalidEnum,
+			wantErr:     wasmgl.ErrInvalidOperation,
+		},
+		{
+			name: "out of memory",
+			prepare: func(b *wasmgltest.Backend) {
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, b.CreateBuffer())
+				b.SetOutOfMemory(true)
+			},
+			wantErr: wasmgl.ErrOutOfMemory,
+		},
+		{
+			name:    "no bound buffer",
+			prepare: func(b *wasmgltest.Backend) {},
+			wantErr: wasmgl.ErrInvalidOperation,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			useBackend(t, b)
+			tc.prepare(b)
+
+			err := wasmgl.BufferDataChecked(wasmgl.ARRAY_BUFFER, 64, nil, wasmgl.STATIC_DRAW)
+			checkErrors(t, err, tc.wantPending, tc.wantErr)
+		})
+	}
+}
+
+func TestCheckedUpload(t *testing.T) {
+	testCases := []struct {
+		name        string
+		call        func(b *wasmgltest.Backend) error
+		wantPending error
+		wantErr     error
+	}{
+		{
+			name: "buffer sub data",
+			call: func(b *wasmgltest.Backend) error {
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, b.CreateBuffer())
+				b.BufferData(wasmgl.ARRAY_BUFFER, 16, nil, wasmgl.STATIC_DRAW)
+				return wasmgl.BufferSubDataChecked(wasmgl.ARRAY_BUFFER, 8, make([]byte, 8))
+			},
+		},
+		{
+			name: "buffer sub data out of range",
+			call: func(b *wasmgltest.Backend) error {
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, b.CreateBuffer())
+				b.BufferData(wasmgl.ARRAY_BUFFER, 16, nil, wasmgl.STATIC_DRAW)
+				return wasmgl.BufferSubDataChecked(wasmgl.ARRAY_BUFFER, 12, make([]byte, 8))
+			},
+			wantErr: wasmgl.ErrInvalidValue,
+		},
+		{
+			name: "get buffer sub data",
+			call: func(b *wasmgltest.Backend) error {
+				b.BindBuffer(wasmgl.COPY_READ_BUFFER, b.CreateBuffer())
+				b.BufferData(wasmgl.COPY_READ_BUFFER, 16, nil, wasmgl.STATIC_READ)
+				return wasmgl.GetBufferSubDataChecked(wasmgl.COPY_READ_BUFFER, 0, make([]float32, 4))
+			},
+		},
+		{
+			name: "get buffer sub data without buffer",
+			call: func(b *wasmgltest.Backend) error {
+				return wasmgl.GetBufferSubDataChecked(wasmgl.COPY_READ_BUFFER, 0, make([]float32, 4))
+			},
+			wantErr: wasmgl.ErrInvalidOperation,
+		},
+		{
+			name: "texture image",
+			call: func(b *wasmgltest.Backend) error {
+				b.BindTexture(wasmgl.TEXTURE_2D, b.CreateTexture())
+				return wasmgl.TexImage2DChecked(wasmgl.TEXTURE_2D, 0, wasmgl.RGBA8, 4, 4, 0, wasmgl.RGBA, wasmgl.UNSIGNED_BYTE, nil)
+			},
+		},
+		{
+			name: "texture image with border",
+			call: func(b *wasmgltest.Backend) error {
+				b.BindTexture(wasmgl.TEXTURE_2D, b.CreateTexture())
+				return wasmgl.TexImage2DChecked(wasmgl.TEXTURE_2D, 0, wasmgl.RGBA8, 4, 4, 1, wasmgl.RGBA, wasmgl.UNSIGNED_BYTE, nil)
+			},
+			wantErr: wasmgl.ErrInvalidValue,
+		},
+		{
+			name: "texture image of texture storage",
+			call: func(b *wasmgltest.Backend) error {
+				b.BindTexture(wasmgl.TEXTURE_2D_ARRAY, b.CreateTexture())
+				b.TexStorage3D(wasmgl.TEXTURE_2D_ARRAY, 1, wasmgl.RGBA8, 4, 4, 2)
+				return wasmgl.TexImage3DChecked(wasmgl.TEXTURE_2D_ARRAY, 0, wasmgl.RGBA8, 4, 4, 2, 0, wasmgl.RGBA, wasmgl.UNSIGNED_BYTE, nil)
+			},
+			wantErr: wasmgl.ErrInvalidOperation,
+		},
+		{
+			name: "texture sub image without texture",
+			call: func(b *wasmgltest.Backend) error {
+				return wasmgl.TexSubImage2DChecked(wasmgl.TEXTURE_2D, 0, 0, 0, 4, 4, wasmgl.RGBA, wasmgl.UNSIGNED_BYTE, make([]byte, 64))
+			},
+			wantErr: wasmgl.ErrInvalidOperation,
+		},
+		{
+			name: "read pixels",
+			call: func(b *wasmgltest.Backend) error {
+				return wasmgl.ReadPixelsDataChecked(0, 0, 2, 2, wasmgl.RGBA, wasmgl.UNSIGNED_BYTE, make([]byte, 16))
+			},
+		},
+		{
+			name: "read pixels with pack buffer",
+			call: func(b *wasmgltest.Backend) error {
+				b.BindBuffer(wasmgl.PIXEL_PACK_BUFFER, b.CreateBuffer())
+				return wasmgl.ReadPixelsDataChecked(0, 0, 2, 2, wasmgl.RGBA, wasmgl.UNSIGNED_BYTE, make([]byte, 16))
+			},
+			wantErr: wasmgl.ErrInvalidOperation,
+		},
+		{
+			name: "texture sub image after context loss",
+			call: func(b *wasmgltest.Backend) error {
+				b.BindTexture(wasmgl.TEXTURE_3D, b.CreateTexture())
+				b.LoseContext()
+				return wasmgl.TexSubImage3DChecked(wasmgl.TEXTURE_3D, 0, 0, 0, 0, 4, 4, 4, wasmgl.RGBA, wasmgl.UNSIGNED_BYTE, make([]byte, 256))
+			},
+			wantPending: wasmgl.ErrContextLost,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			useBackend(t, b)
+
+			err := tc.call(b)
+			checkErrors(t, err, tc.wantPending, tc.wantErr)
+		})
+	}
+}
+
+// checkErrors verifies that err holds the wanted pending errors as a
+// *PendingError and that the remaining errors match wantErr.
+func checkErrors(t *testing.T, err, wantPending, wantErr error) {
+	t.Helper()
+	var pending *wasmgl.PendingError
+	switch hasPending := errors.As(err, &pending); {
+	case wantPending == nil && hasPending:
+		t.Errorf("unexpected pending error: %v", pending)
+	case wantPending != nil && !hasPending:
+		t.Errorf("got error %v, want pending %v", err, wantPending)
+	case wantPending != nil && !errors.Is(pending, wantPending):
+		t.Errorf("got pending error %v, want %v", pending, wantPending)
+	}
+	if wantErr == nil {
+		if err != nil && err != error(pending) {
+			t.Errorf("unexpected error: %v", err)
+		}
+		return
+	}
+	if !errors.Is(err, wantErr) {
+		t.Errorf("got error %v, want %v", err, wantErr)
+	}
+}
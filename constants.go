@@ -1,5 +1,3 @@
-//go:build js && wasm
-
 package wasmgl
 
 // NOTE: The following constants do not have the GLenum type on purpose.
@@ -607,6 +605,8 @@ const (
 	CONDITION_SATISFIED                           = 0x911C
 	WAIT_FAILED                                   = 0x911D
 	SYNC_FLUSH_COMMANDS_BIT                       = 0x00000001
+	TIMEOUT_IGNORED                               = -1
+	MAX_CLIENT_WAIT_TIMEOUT_WEBGL                 = 0x9247
 	VERTEX_ATTRIB_ARRAY_DIVISOR                   = 0x88FE
 	ANY_SAMPLES_PASSED                            = 0x8C2F
 	ANY_SAMPLES_PASSED_CONSERVATIVE               = 0x8D6A
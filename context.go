@@ -3,7 +3,9 @@
 package wasmgl
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 	"syscall/js"
 )
 
@@ -12,6 +14,28 @@ var context js.Value
 // ContextOption represents a configuration option for the WebGL2 context.
 type ContextOption func(v js.Value)
 
+// contextConfig holds the configuration of options that cannot be expressed
+// as context attributes.
+type contextConfig struct {
+	debugHandler        DebugHandler
+	colorSpace          ColorSpace
+	drawingBufferFormat GLenum
+}
+
+// configuring is the contextConfig of the context that is being created.
+// It is only set while the options are applied.
+var configuring *contextConfig
+
+// configure returns a ContextOption that changes the contextConfig of the
+// context that is being created instead of its attributes.
+func configure(fn func(c *contextConfig)) ContextOption {
+	return func(v js.Value) {
+		if configuring != nil {
+			fn(configuring)
+		}
+	}
+}
+
 // WithOptionAlpha configures the alpha context option.
 func WithOptionAlpha(alpha bool) ContextOption {
 	return func(v js.Value) {
@@ -91,6 +115,51 @@ func WithOptionXRCompatible(compatible bool) ContextOption {
 	}
 }
 
+// WithOptionColorSpace configures the drawingBufferColorSpace of the context
+// once it has been created. The option is ignored if the browser does not
+// support the color space (see ColorSpaceSupported).
+//
+// Unlike the attribute options, this option does not change the js.Value
+// that it is applied to and only takes effect when it is passed to one of
+// the Init functions.
+func WithOptionColorSpace(colorSpace ColorSpace) ContextOption {
+	return configure(func(c *contextConfig) {
+		c.colorSpace = colorSpace
+	})
+}
+
+// WithOptionDrawingBufferFormat reallocates the drawing buffer of the
+// context with the specified internal format (e.g. RGBA16F for HDR output)
+// once it has been created. The option is ignored if the browser does not
+// support drawingBufferStorage (see DrawingBufferStorageSupported).
+//
+// Unlike the attribute options, this option does not change the js.Value
+// that it is applied to and only takes effect when it is passed to one of
+// the Init functions.
+func WithOptionDrawingBufferFormat(internalFormat GLenum) ContextOption {
+	return configure(func(c *contextConfig) {
+		c.drawingBufferFormat = internalFormat
+	})
+}
+
+// WithOptionDebug enables the debug mode, where GetError is checked after
+// each call and the specified handler is notified of any errors. If handler
+// is nil, then PanicDebugHandler is used.
+//
+// Unlike the attribute options, this option does not change the js.Value
+// that it is applied to and only takes effect when it is passed to one of
+// the Init functions.
+//
+// See NewDebugBackend for more information.
+func WithOptionDebug(handler DebugHandler) ContextOption {
+	return configure(func(c *contextConfig) {
+		if handler == nil {
+			handler = PanicDebugHandler
+		}
+		c.debugHandler = handler
+	})
+}
+
 // InitFromID initializes webgl context and bindings
 // from the canvas that has the specified canvasID ID.
 func InitFromID(canvasID string, opts ...ContextOption) error {
@@ -107,15 +176,276 @@ func InitFromID(canvasID string, opts ...ContextOption) error {
 
 // InitFromCanvas initializes webgl context and bindings
 // from the specified htmlCanvas canvas element reference.
+//
+// If the context cannot be acquired, then a *ContextError is returned that
+// describes the reason (e.g. errors.Is(err, ErrContextUnsupported)).
 func InitFromCanvas(htmlCanvas js.Value, opts ...ContextOption) error {
-	optsObject := js.Global().Get("Object").New()
-	for _, opt := range opts {
-		opt(optsObject)
+	_, err := InitFromCanvasWithFallbacks(htmlCanvas, opts)
+	return err
+}
+
+// InitFromOffscreenCanvas initializes webgl context and bindings from the
+// specified OffscreenCanvas reference. Unlike InitFromID, this function
+// does not depend on the document and can be used inside a Web Worker.
+//
+// See ReceiveOffscreenCanvas for obtaining a canvas that has been
+// transferred to a worker.
+func InitFromOffscreenCanvas(offscreenCanvas js.Value, opts ...ContextOption) error {
+	if offscreenCanvas.Type() != js.TypeObject {
+		return fmt.Errorf("offscreen canvas is not specified")
+	}
+	return InitFromCanvas(offscreenCanvas, opts...)
+}
+
+// InitFromCanvasWithFallbacks initializes webgl context and bindings from
+// the specified htmlCanvas canvas element (or OffscreenCanvas) reference,
+// trying each of the specified option sets in order until a context can be
+// acquired. The attributes of the acquired context are returned, since
+// browsers are free to not grant some of the requested ones (e.g.
+// antialias).
+//
+// If none of the option sets succeeds, then the *ContextError of the
+// attempt is returned. If more than one option set was tried, then the
+// *ContextError values of all attempts are combined through errors.Join.
+func InitFromCanvasWithFallbacks(htmlCanvas js.Value, optionSets ...[]ContextOption) (ContextAttributes, error) {
+	if len(optionSets) == 0 {
+		optionSets = [][]ContextOption{nil}
+	}
+	var errs []error
+	for _, opts := range optionSets {
+		attributes := js.Global().Get("Object").New()
+		var config contextConfig
+		configuring = &config
+		for _, opt := range opts {
+			opt(attributes)
+		}
+		configuring = nil
+		glContext, err := createContext(htmlCanvas, attributes)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		context = glContext
+		initFunctions(context)
+		var glBackend Backend = jsBackend{}
+		if config.debugHandler != nil {
+			glBackend = NewDebugBackend(glBackend, config.debugHandler)
+		}
+		SetBackend(glBackend)
+		if config.colorSpace != "" && ColorSpaceSupported(config.colorSpace) {
+			SetDrawingBufferColorSpace(config.colorSpace)
+		}
+		if config.drawingBufferFormat != 0 && DrawingBufferStorageSupported() {
+			DrawingBufferStorage(config.drawingBufferFormat, GLsizei(DrawingBufferWidth()), GLsizei(DrawingBufferHeight()))
+		}
+		return GetContextAttributes(), nil
+	}
+	if len(errs) == 1 {
+		return ContextAttributes{}, errs[0]
+	}
+	return ContextAttributes{}, errors.Join(errs...)
+}
+
+// ContextAttributes holds the actual attributes of a WebGL2 context, as
+// returned by getContextAttributes.
+type ContextAttributes struct {
+	Alpha                        bool
+	Depth                        bool
+	Stencil                      bool
+	Desynchronized               bool
+	Antialias                    bool
+	FailIfMajorPerformanceCaveat bool
+	PowerPreference              PowerPreference
+	PremultipliedAlpha           bool
+	PreserveDrawingBuffer        bool
+	XRCompatible                 bool
+}
+
+// GetContextAttributes returns the actual attributes of the current
+// context. The zero value is returned if the context has been lost.
+func GetContextAttributes() ContextAttributes {
+	attributes := context.Call("getContextAttributes")
+	if attributes.IsNull() {
+		return ContextAttributes{}
+	}
+	return ContextAttributes{
+		Alpha:                        attributes.Get("alpha").Truthy(),
+		Depth:                        attributes.Get("depth").Truthy(),
+		Stencil:                      attributes.Get("stencil").Truthy(),
+		Desynchronized:               attributes.Get("desynchronized").Truthy(),
+		Antialias:                    attributes.Get("antialias").Truthy(),
+		FailIfMajorPerformanceCaveat: attributes.Get("failIfMajorPerformanceCaveat").Truthy(),
+		PowerPreference:              PowerPreference(stringOr(attributes.Get("powerPreference"), string(PowerPreferenceDefault))),
+		PremultipliedAlpha:           attributes.Get("premultipliedAlpha").Truthy(),
+		PreserveDrawingBuffer:        attributes.Get("preserveDrawingBuffer").Truthy(),
+		XRCompatible:                 attributes.Get("xrCompatible").Truthy(),
+	}
+}
+
+// ColorSpaceSupported returns whether the current context can output to the
+// specified color space through SetDrawingBufferColorSpace.
+func ColorSpaceSupported(colorSpace ColorSpace) bool {
+	current := context.Get("drawingBufferColorSpace")
+	if current.Type() != js.TypeString {
+		return colorSpace == ColorSpaceSRGB
+	}
+	// Browsers ignore unsupported values, so the support can be detected by
+	// reading the value back.
+	context.Set("drawingBufferColorSpace", string(colorSpace))
+	supported := context.Get("drawingBufferColorSpace").String() == string(colorSpace)
+	context.Set("drawingBufferColorSpace", current)
+	return supported
+}
+
+// DrawingBufferStorageSupported returns whether the current context
+// supports DrawingBufferStorage.
+func DrawingBufferStorageSupported() bool {
+	return context.Get("drawingBufferStorage").Type() == js.TypeFunction
+}
+
+// DisplaySupportsColorSpace returns whether the display is capable of
+// showing (approximately) the gamut of the specified color space. Rendering
+// in a wide-gamut color space is only worthwhile if this is the case.
+//
+// Inside workers the display cannot be queried and only ColorSpaceSRGB is
+// reported as supported.
+func DisplaySupportsColorSpace(colorSpace ColorSpace) bool {
+	if colorSpace == ColorSpaceSRGB {
+		return true
+	}
+	matchMedia := js.Global().Get("matchMedia")
+	if matchMedia.Type() != js.TypeFunction {
+		return false
+	}
+	switch colorSpace {
+	case ColorSpaceDisplayP3:
+		return js.Global().Call("matchMedia", "(color-gamut: p3)").Get("matches").Truthy()
+	default:
+		return false
+	}
+}
+
+// stringOr returns the specified value as a string or fallback if the value
+// is not a string.
+func stringOr(value js.Value, fallback string) string {
+	if value.Type() != js.TypeString {
+		return fallback
+	}
+	return value.String()
+}
+
+// createContext acquires a WebGL2 context from the specified canvas. If that
+// is not possible, a *ContextError is returned that includes the status
+// message of the webglcontextcreationerror event.
+func createContext(htmlCanvas, attributes js.Value) (js.Value, error) {
+	var (
+		dispatched    bool
+		statusMessage string
+	)
+	listener := js.FuncOf(func(this js.Value, args []js.Value) any {
+		dispatched = true
+		if len(args) > 0 {
+			if message := args[0].Get("statusMessage"); message.Type() == js.TypeString {
+				statusMessage = message.String()
+			}
+		}
+		return nil
+	})
+	defer listener.Release()
+
+	// NOTE: The event is dispatched synchronously from within getContext.
+	htmlCanvas.Call("addEventListener", "webglcontextcreationerror", listener)
+	defer htmlCanvas.Call("removeEventListener", "webglcontextcreationerror", listener)
+
+	glContext := htmlCanvas.Call("getContext", "webgl2", attributes)
+	if !glContext.IsNull() {
+		return glContext, nil
+	}
+	return js.Null(), &ContextError{
+		Reason:        contextErrorReason(htmlCanvas, attributes, dispatched, statusMessage),
+		StatusMessage: statusMessage,
+	}
+}
+
+// contextErrorReason determines why a context with the specified attributes
+// could not be created. The reason is only reported if there is evidence
+// for it, otherwise ContextErrorUnknown is returned.
+func contextErrorReason(htmlCanvas, attributes js.Value, dispatched bool, statusMessage string) ContextErrorReason {
+	if js.Global().Get("WebGL2RenderingContext").IsUndefined() {
+		return ContextErrorUnsupported
+	}
+	// Browsers dispatch the creation error event only if they attempted to
+	// create a context, which is not the case if the canvas already holds
+	// a context of a different type.
+	if !dispatched && canvasInUse(htmlCanvas) {
+		return ContextErrorCanvasInUse
+	}
+	message := strings.ToLower(statusMessage)
+	switch {
+	case containsAny(message, "blocklist", "blacklist", "blocked", "disabled"):
+		return ContextErrorBlocklisted
+	case strings.Contains(message, "caveat"):
+		return ContextErrorPerformanceCaveat
+	}
+	if attributes.Get("failIfMajorPerformanceCaveat").Truthy() && canCreateWithoutCaveat(attributes) {
+		return ContextErrorPerformanceCaveat
+	}
+	return ContextErrorUnknown
+}
+
+// canCreateWithoutCaveat checks whether a context with the specified
+// attributes but without failIfMajorPerformanceCaveat can be created on a
+// separate canvas, so that the original canvas remains unused.
+func canCreateWithoutCaveat(attributes js.Value) bool {
+	probeCanvas, ok := newProbeCanvas()
+	if !ok {
+		return false
+	}
+	probeAttributes := js.Global().Get("Object").Call("assign", js.Global().Get("Object").New(), attributes)
+	probeAttributes.Set("failIfMajorPerformanceCaveat", false)
+	probe := probeCanvas.Call("getContext", "webgl2", probeAttributes)
+	if probe.IsNull() {
+		return false
+	}
+	if ext := probe.Call("getExtension", "WEBGL_lose_context"); !ext.IsNull() {
+		ext.Call("loseContext")
+	}
+	return true
+}
+
+// canvasInUse checks whether the specified canvas holds a context of a
+// different type, in which case getContext returns the existing context
+// for that type.
+//
+// NOTE: A canvas without any context would get one from this check, which
+// is why it is only done if the browser did not attempt to create the
+// WebGL2 context in the first place.
+func canvasInUse(htmlCanvas js.Value) bool {
+	for _, contextType := range []string{"webgl", "2d", "bitmaprenderer"} {
+		if existing := htmlCanvas.Call("getContext", contextType); !existing.IsNull() {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAny(s string, substrs ...string) bool {
+	for _, substr := range substrs {
+		if strings.Contains(s, substr) {
+			return true
+		}
+	}
+	return false
+}
+
+// newProbeCanvas returns a new canvas that is not attached to any document.
+// An OffscreenCanvas is preferred, since it is also available in workers.
+func newProbeCanvas() (js.Value, bool) {
+	if offscreenCanvas := js.Global().Get("OffscreenCanvas"); !offscreenCanvas.IsUndefined() {
+		return offscreenCanvas.New(1, 1), true
 	}
-	context = htmlCanvas.Call("getContext", "webgl2", optsObject)
-	if context.IsNull() {
-		return fmt.Errorf("could not acquire webgl2 context")
+	if htmlDocument := js.Global().Get("document"); !htmlDocument.IsUndefined() {
+		return htmlDocument.Call("createElement", "canvas"), true
 	}
-	initFunctions(context)
-	return nil
+	return js.Undefined(), false
 }
@@ -0,0 +1,32 @@
+package wasmgl
+
+import "unsafe"
+
+// DataTypes represents allowed data slice types.
+type DataTypes interface {
+	~int8 | ~uint8 | ~int16 | ~uint16 | ~int32 | ~uint32 | ~float32 | ~float64
+}
+
+// asByteSlice returns a []byte representation for the
+// specified arbitrary slice type.
+//
+// This utility function is related to the following issues:
+// https://github.com/golang/go/issues/32402
+// https://github.com/golang/go/issues/31980
+func asByteSlice[T DataTypes](data []T) []byte {
+	if len(data) == 0 {
+		return nil
+	}
+	dataSize := byteSize(data)
+	return unsafe.Slice((*byte)(unsafe.Pointer(&data[0])), dataSize)
+}
+
+// byteSize returns the number of bytes that would be
+// needed to represent data once it is converted to a
+// byte slice through asByteSlice.
+func byteSize[T DataTypes](data []T) int {
+	if len(data) == 0 {
+		return 0
+	}
+	return len(data) * int(unsafe.Sizeof(data[0]))
+}
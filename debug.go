@@ -0,0 +1,1508 @@
+package wasmgl
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// DebugHandler is called by a debug Backend whenever a call results in
+// a WebGL error.
+type DebugHandler func(report DebugReport)
+
+// PanicDebugHandler is a DebugHandler that panics with the DebugReport.
+func PanicDebugHandler(report DebugReport) {
+	panic(report)
+}
+
+// LogDebugHandler returns a DebugHandler that logs all reports as errors
+// through the specified logger. If logger is nil, then slog.Default is used.
+func LogDebugHandler(logger *slog.Logger) DebugHandler {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return func(report DebugReport) {
+		logger.Error("WebGL call failed",
+			slog.String("function", report.Function),
+			slog.String("args", report.formatArgs()),
+			slog.String("error", EnumName(report.Code, EnumCategoryError)),
+			slog.String("caller", report.Caller()),
+		)
+	}
+}
+
+// DebugReport describes a call that resulted in a WebGL error.
+type DebugReport struct {
+
+	// Function is the name of the function that was called.
+	Function string
+
+	// Args holds the arguments that were passed to the function.
+	Args []DebugArg
+
+	// Code is the error code that was returned by GetError after the call.
+	Code GLenum
+
+	// Stack holds the Go call stack, starting with the frame that called
+	// into this package.
+	Stack []runtime.Frame
+}
+
+// Caller returns the location of the code that made the failing call
+// in the form file:line.
+func (r DebugReport) Caller() string {
+	if len(r.Stack) == 0 {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", r.Stack[0].File, r.Stack[0].Line)
+}
+
+// Unwrap returns the GLError of the failed call, which allows the use of
+// errors.Is to check for specific error codes.
+func (r DebugReport) Unwrap() error {
+	return GLError(r.Code)
+}
+
+// Error returns a description of the failed call, including the Go call
+// stack.
+func (r DebugReport) Error() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "%s(%s) resulted in %s", r.Function, r.formatArgs(), EnumName(r.Code, EnumCategoryError))
+	for _, frame := range r.Stack {
+		fmt.Fprintf(&builder, "\n\t%s\n\t\t%s:%d", frame.Function, frame.File, frame.Line)
+	}
+	return builder.String()
+}
+
+func (r DebugReport) formatArgs() string {
+	args := make([]string, len(r.Args))
+	for i, arg := range r.Args {
+		args[i] = arg.String()
+	}
+	return strings.Join(args, ", ")
+}
+
+// DebugArg represents an argument of a failed call.
+type DebugArg struct {
+
+	// Value holds the value of the argument.
+	Value any
+
+	// Enum indicates whether the argument is an enum value, in which case
+	// it is formatted by name.
+	Enum bool
+
+	// Category indicates the context of the enum value, which is used to
+	// pick the correct name for values that are shared by multiple
+	// constants.
+	Category EnumCategory
+}
+
+// String returns a human-readable representation of the argument.
+func (a DebugArg) String() string {
+	if a.Enum {
+		switch v := a.Value.(type) {
+		case GLenum:
+			return EnumName(v, a.Category)
+		case []GLenum:
+			names := make([]string, len(v))
+			for i, value := range v {
+				names[i] = EnumName(value, a.Category)
+			}
+			return "[" + strings.Join(names, " ") + "]"
+		}
+	}
+	value := reflect.ValueOf(a.Value)
+	switch {
+	case !value.IsValid():
+		return "nil"
+	case value.Kind() == reflect.Slice && value.Len() > 16:
+		return fmt.Sprintf("%s(len=%d)", value.Type(), value.Len())
+	}
+	if handle, ok := a.Value.(interface{ IsValid() bool }); ok {
+		if stringer, ok := handle.(fmt.Stringer); ok {
+			return stringer.String()
+		}
+		name := value.Type().Name()
+		if !handle.IsValid() {
+			return "Nil" + name
+		}
+		return name
+	}
+	return fmt.Sprintf("%v", a.Value)
+}
+
+func enumArg(value GLenum, category EnumCategory) DebugArg {
+	return DebugArg{
+		Value:    value,
+		Enum:     true,
+		Category: category,
+	}
+}
+
+func enumsArg(values []GLenum, category EnumCategory) DebugArg {
+	return DebugArg{
+		Value:    values,
+		Enum:     true,
+		Category: category,
+	}
+}
+
+func valueArg(value any) DebugArg {
+	return DebugArg{
+		Value: value,
+	}
+}
+
+var packagePrefix = reflect.TypeOf(debugBackend{}).PkgPath() + "."
+
+// NewDebugBackend returns a Backend that forwards all calls to the
+// specified delegate and calls GetError after each one of them. Whenever
+// an error is detected, the specified handler is called with a report that
+// contains the name of the failing function, its arguments and the Go call
+// stack. If handler is nil, then PanicDebugHandler is used.
+//
+// Detected errors remain pending, so GetError and CheckError still report
+// them to the caller, in the order in which they were detected.
+//
+// The check after each call comes at a significant performance cost, so
+// the debug Backend should only be used during development.
+func NewDebugBackend(delegate Backend, handler DebugHandler) Backend {
+	if handler == nil {
+		handler = PanicDebugHandler
+	}
+	return &debugBackend{
+		delegate:       delegate,
+		handler:        handler,
+		textureTargets: make(map[Texture]GLenum),
+	}
+}
+
+var _ Backend = (*debugBackend)(nil)
+
+type debugBackend struct {
+	delegate Backend
+	handler  DebugHandler
+
+	// pending holds the distinct error codes that have been observed but
+	// not yet returned through GetError, so that the checks of the debug
+	// Backend do not hide errors from the caller.
+	pending []GLenum
+
+	// textureTargets holds the targets that textures have been bound to,
+	// which determine the valid texTarget values for FramebufferTexture2D.
+	textureTargets map[Texture]GLenum
+}
+
+// checkError returns the error code of the last call and keeps it pending
+// for GetError.
+func (b *debugBackend) checkError() GLenum {
+	code := b.delegate.GetError()
+	if code != NO_ERROR && !slices.Contains(b.pending, code) {
+		b.pending = append(b.pending, code)
+	}
+	return code
+}
+
+func (b *debugBackend) report(code GLenum, function string, args ...DebugArg) {
+	b.handler(DebugReport{
+		Function: function,
+		Args:     args,
+		Code:     code,
+		Stack:    callerStack(),
+	})
+}
+
+// callerStack returns the current call stack, excluding the leading frames
+// that belong to this package.
+func callerStack() []runtime.Frame {
+	pcs := make([]uintptr, 64)
+	count := runtime.Callers(2, pcs)
+	frames := runtime.CallersFrames(pcs[:count])
+	var result []runtime.Frame
+	for {
+		frame, more := frames.Next()
+		if len(result) > 0 || !strings.HasPrefix(frame.Function, packagePrefix) {
+			result = append(result, frame)
+		}
+		if !more {
+			break
+		}
+	}
+	return result
+}
+
+func (b *debugBackend) ActiveTexture(texture GLenum) {
+	b.delegate.ActiveTexture(texture)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ActiveTexture", enumArg(texture, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) AttachShader(program Program, shader Shader) {
+	b.delegate.AttachShader(program, shader)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "AttachShader", valueArg(program), valueArg(shader))
+	}
+}
+
+func (b *debugBackend) BindBuffer(target GLenum, buffer Buffer) {
+	b.delegate.BindBuffer(target, buffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BindBuffer", enumArg(target, EnumCategoryBufferTarget), valueArg(buffer))
+	}
+}
+
+func (b *debugBackend) BindBufferBase(target GLenum, index GLuint, buffer Buffer) {
+	b.delegate.BindBufferBase(target, index, buffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BindBufferBase", enumArg(target, EnumCategoryBufferTarget), valueArg(index), valueArg(buffer))
+	}
+}
+
+func (b *debugBackend) BindBufferRange(target GLenum, index GLuint, buffer Buffer, offset GLintptr, size GLsizeiptr) {
+	b.delegate.BindBufferRange(target, index, buffer, offset, size)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BindBufferRange", enumArg(target, EnumCategoryBufferTarget), valueArg(index), valueArg(buffer), valueArg(offset), valueArg(size))
+	}
+}
+
+func (b *debugBackend) BindFramebuffer(target GLenum, framebuffer Framebuffer) {
+	b.delegate.BindFramebuffer(target, framebuffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BindFramebuffer", enumArg(target, EnumCategoryAny), valueArg(framebuffer))
+	}
+}
+
+func (b *debugBackend) BindRenderbuffer(target GLenum, renderbuffer Renderbuffer) {
+	b.delegate.BindRenderbuffer(target, renderbuffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BindRenderbuffer", enumArg(target, EnumCategoryAny), valueArg(renderbuffer))
+	}
+}
+
+func (b *debugBackend) BindSampler(unit GLuint, sampler Sampler) {
+	b.delegate.BindSampler(unit, sampler)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BindSampler", valueArg(unit), valueArg(sampler))
+	}
+}
+
+func (b *debugBackend) BindTexture(target GLenum, texture Texture) {
+	b.delegate.BindTexture(target, texture)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BindTexture", enumArg(target, EnumCategoryAny), valueArg(texture))
+	} else if texture.IsValid() {
+		b.textureTargets[texture] = target
+	}
+}
+
+func (b *debugBackend) BindVertexArray(array VertexArray) {
+	b.delegate.BindVertexArray(array)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BindVertexArray", valueArg(array))
+	}
+}
+
+func (b *debugBackend) BlendColor(red, green, blue, alpha GLclampf) {
+	b.delegate.BlendColor(red, green, blue, alpha)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BlendColor", valueArg(red), valueArg(green), valueArg(blue), valueArg(alpha))
+	}
+}
+
+func (b *debugBackend) BlendEquation(mode GLenum) {
+	b.delegate.BlendEquation(mode)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BlendEquation", enumArg(mode, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) BlendEquationSeparate(modeRGB, modeAlpha GLenum) {
+	b.delegate.BlendEquationSeparate(modeRGB, modeAlpha)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BlendEquationSeparate", enumArg(modeRGB, EnumCategoryAny), enumArg(modeAlpha, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) BlendFunc(sfactor, dfactor GLenum) {
+	b.delegate.BlendFunc(sfactor, dfactor)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BlendFunc", enumArg(sfactor, EnumCategoryBlendFactor), enumArg(dfactor, EnumCategoryBlendFactor))
+	}
+}
+
+func (b *debugBackend) BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha GLenum) {
+	b.delegate.BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BlendFuncSeparate", enumArg(srcRGB, EnumCategoryBlendFactor), enumArg(dstRGB, EnumCategoryBlendFactor), enumArg(srcAlpha, EnumCategoryBlendFactor), enumArg(dstAlpha, EnumCategoryBlendFactor))
+	}
+}
+
+func (b *debugBackend) BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1 GLint, mask GLbitfield, filter GLenum) {
+	b.delegate.BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1, mask, filter)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BlitFramebuffer", valueArg(srcX0), valueArg(srcY0), valueArg(srcX1), valueArg(srcY1), valueArg(dstX0), valueArg(dstY0), valueArg(dstX1), valueArg(dstY1), valueArg(mask), enumArg(filter, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) BufferData(target GLenum, size GLsizeiptr, data []byte, usage GLenum) {
+	b.delegate.BufferData(target, size, data, usage)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BufferData", enumArg(target, EnumCategoryBufferTarget), valueArg(size), valueArg(data), enumArg(usage, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) BufferSubData(target GLenum, dstOffset GLintptr, data []byte) {
+	b.delegate.BufferSubData(target, dstOffset, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "BufferSubData", enumArg(target, EnumCategoryBufferTarget), valueArg(dstOffset), valueArg(data))
+	}
+}
+
+func (b *debugBackend) CheckFramebufferStatus(target GLenum) GLenum {
+	result := b.delegate.CheckFramebufferStatus(target)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CheckFramebufferStatus", enumArg(target, EnumCategoryAny))
+	}
+	return result
+}
+
+func (b *debugBackend) Clear(mask GLbitfield) {
+	b.delegate.Clear(mask)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Clear", valueArg(mask))
+	}
+}
+
+func (b *debugBackend) ClearBufferfv(buffer GLenum, drawBuffer GLint, values Float32List) {
+	b.delegate.ClearBufferfv(buffer, drawBuffer, values)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearBufferfv", enumArg(buffer, EnumCategoryAny), valueArg(drawBuffer), valueArg(values))
+	}
+}
+
+func (b *debugBackend) ClearBufferfvOffset(buffer GLenum, drawBuffer GLint, values Float32List, srcOffset GLuint) {
+	b.delegate.ClearBufferfvOffset(buffer, drawBuffer, values, srcOffset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearBufferfvOffset", enumArg(buffer, EnumCategoryAny), valueArg(drawBuffer), valueArg(values), valueArg(srcOffset))
+	}
+}
+
+func (b *debugBackend) ClearBufferiv(buffer GLenum, drawBuffer GLint, values Int32List) {
+	b.delegate.ClearBufferiv(buffer, drawBuffer, values)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearBufferiv", enumArg(buffer, EnumCategoryAny), valueArg(drawBuffer), valueArg(values))
+	}
+}
+
+func (b *debugBackend) ClearBufferivOffset(buffer GLenum, drawBuffer GLint, values Int32List, srcOffset GLuint) {
+	b.delegate.ClearBufferivOffset(buffer, drawBuffer, values, srcOffset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearBufferivOffset", enumArg(buffer, EnumCategoryAny), valueArg(drawBuffer), valueArg(values), valueArg(srcOffset))
+	}
+}
+
+func (b *debugBackend) ClearBufferuiv(buffer GLenum, drawBuffer GLint, values Uint32List) {
+	b.delegate.ClearBufferuiv(buffer, drawBuffer, values)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearBufferuiv", enumArg(buffer, EnumCategoryAny), valueArg(drawBuffer), valueArg(values))
+	}
+}
+
+func (b *debugBackend) ClearBufferuivOffset(buffer GLenum, drawBuffer GLint, values Uint32List, srcOffset GLuint) {
+	b.delegate.ClearBufferuivOffset(buffer, drawBuffer, values, srcOffset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearBufferuivOffset", enumArg(buffer, EnumCategoryAny), valueArg(drawBuffer), valueArg(values), valueArg(srcOffset))
+	}
+}
+
+func (b *debugBackend) ClearBufferfi(buffer GLenum, drawBuffer GLint, depth GLfloat, stencil GLint) {
+	b.delegate.ClearBufferfi(buffer, drawBuffer, depth, stencil)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearBufferfi", enumArg(buffer, EnumCategoryAny), valueArg(drawBuffer), valueArg(depth), valueArg(stencil))
+	}
+}
+
+func (b *debugBackend) ClearColor(red, green, blue, alpha GLclampf) {
+	b.delegate.ClearColor(red, green, blue, alpha)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearColor", valueArg(red), valueArg(green), valueArg(blue), valueArg(alpha))
+	}
+}
+
+func (b *debugBackend) ClearDepth(depth GLclampf) {
+	b.delegate.ClearDepth(depth)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearDepth", valueArg(depth))
+	}
+}
+
+func (b *debugBackend) ClearStencil(stencil GLint) {
+	b.delegate.ClearStencil(stencil)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClearStencil", valueArg(stencil))
+	}
+}
+
+func (b *debugBackend) ClientWaitSync(sync Sync, flags GLbitfield, timeout GLuint64) GLenum {
+	result := b.delegate.ClientWaitSync(sync, flags, timeout)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ClientWaitSync", valueArg(sync), valueArg(flags), valueArg(timeout))
+	}
+	return result
+}
+
+func (b *debugBackend) ColorMask(red, green, blue, alpha GLboolean) {
+	b.delegate.ColorMask(red, green, blue, alpha)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ColorMask", valueArg(red), valueArg(green), valueArg(blue), valueArg(alpha))
+	}
+}
+
+func (b *debugBackend) CompileShader(shader Shader) {
+	b.delegate.CompileShader(shader)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CompileShader", valueArg(shader))
+	}
+}
+
+func (b *debugBackend) CompressedTexImage2D(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, data []byte) {
+	b.delegate.CompressedTexImage2D(target, level, internalFormat, width, height, border, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CompressedTexImage2D", enumArg(target, EnumCategoryAny), valueArg(level), enumArg(internalFormat, EnumCategoryAny), valueArg(width), valueArg(height), valueArg(border), valueArg(data))
+	}
+}
+
+func (b *debugBackend) CompressedTexImage2DOffset(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, imageSize GLsizei, offset GLintptr) {
+	b.delegate.CompressedTexImage2DOffset(target, level, internalFormat, width, height, border, imageSize, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CompressedTexImage2DOffset", enumArg(target, EnumCategoryAny), valueArg(level), enumArg(internalFormat, EnumCategoryAny), valueArg(width), valueArg(height), valueArg(border), valueArg(imageSize), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) CompressedTexImage3D(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, data []byte) {
+	b.delegate.CompressedTexImage3D(target, level, internalFormat, width, height, depth, border, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CompressedTexImage3D", enumArg(target, EnumCategoryAny), valueArg(level), enumArg(internalFormat, EnumCategoryAny), valueArg(width), valueArg(height), valueArg(depth), valueArg(border), valueArg(data))
+	}
+}
+
+func (b *debugBackend) CompressedTexImage3DOffset(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, imageSize GLsizei, offset GLintptr) {
+	b.delegate.CompressedTexImage3DOffset(target, level, internalFormat, width, height, depth, border, imageSize, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CompressedTexImage3DOffset", enumArg(target, EnumCategoryAny), valueArg(level), enumArg(internalFormat, EnumCategoryAny), valueArg(width), valueArg(height), valueArg(depth), valueArg(border), valueArg(imageSize), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) CompressedTexSubImage2D(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format GLenum, data []byte) {
+	b.delegate.CompressedTexSubImage2D(target, level, xoffset, yoffset, width, height, format, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CompressedTexSubImage2D", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(width), valueArg(height), enumArg(format, EnumCategoryAny), valueArg(data))
+	}
+}
+
+func (b *debugBackend) CompressedTexSubImage2DOffset(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format GLenum, imageSize GLsizei, offset GLintptr) {
+	b.delegate.CompressedTexSubImage2DOffset(target, level, xoffset, yoffset, width, height, format, imageSize, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CompressedTexSubImage2DOffset", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(width), valueArg(height), enumArg(format, EnumCategoryAny), valueArg(imageSize), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) CompressedTexSubImage3D(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format GLenum, data []byte) {
+	b.delegate.CompressedTexSubImage3D(target, level, xoffset, yoffset, zoffset, width, height, depth, format, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CompressedTexSubImage3D", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(zoffset), valueArg(width), valueArg(height), valueArg(depth), enumArg(format, EnumCategoryAny), valueArg(data))
+	}
+}
+
+func (b *debugBackend) CompressedTexSubImage3DOffset(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format GLenum, imageSize GLsizei, offset GLintptr) {
+	b.delegate.CompressedTexSubImage3DOffset(target, level, xoffset, yoffset, zoffset, width, height, depth, format, imageSize, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CompressedTexSubImage3DOffset", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(zoffset), valueArg(width), valueArg(height), valueArg(depth), enumArg(format, EnumCategoryAny), valueArg(imageSize), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) CopyBufferSubData(readTarget, writeTarget GLenum, readOffset, writeOffset GLintptr, size GLsizeiptr) {
+	b.delegate.CopyBufferSubData(readTarget, writeTarget, readOffset, writeOffset, size)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CopyBufferSubData", enumArg(readTarget, EnumCategoryBufferTarget), enumArg(writeTarget, EnumCategoryBufferTarget), valueArg(readOffset), valueArg(writeOffset), valueArg(size))
+	}
+}
+
+func (b *debugBackend) CopyTexImage2D(target GLenum, level GLint, internalFormat GLenum, x, y GLint, width, height GLsizei, border GLint) {
+	b.delegate.CopyTexImage2D(target, level, internalFormat, x, y, width, height, border)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CopyTexImage2D", enumArg(target, EnumCategoryAny), valueArg(level), enumArg(internalFormat, EnumCategoryAny), valueArg(x), valueArg(y), valueArg(width), valueArg(height), valueArg(border))
+	}
+}
+
+func (b *debugBackend) CopyTexSubImage2D(target GLenum, level, xoffset, yoffset, x, y GLint, width, height GLsizei) {
+	b.delegate.CopyTexSubImage2D(target, level, xoffset, yoffset, x, y, width, height)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CopyTexSubImage2D", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(x), valueArg(y), valueArg(width), valueArg(height))
+	}
+}
+
+func (b *debugBackend) CopyTexSubImage3D(target GLenum, level, xoffset, yoffset, zoffset, x, y GLint, width, height GLsizei) {
+	b.delegate.CopyTexSubImage3D(target, level, xoffset, yoffset, zoffset, x, y, width, height)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CopyTexSubImage3D", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(zoffset), valueArg(x), valueArg(y), valueArg(width), valueArg(height))
+	}
+}
+
+func (b *debugBackend) CreateBuffer() Buffer {
+	result := b.delegate.CreateBuffer()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CreateBuffer")
+	}
+	return result
+}
+
+func (b *debugBackend) CreateFramebuffer() Framebuffer {
+	result := b.delegate.CreateFramebuffer()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CreateFramebuffer")
+	}
+	return result
+}
+
+func (b *debugBackend) CreateProgram() Program {
+	result := b.delegate.CreateProgram()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CreateProgram")
+	}
+	return result
+}
+
+func (b *debugBackend) CreateRenderbuffer() Renderbuffer {
+	result := b.delegate.CreateRenderbuffer()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CreateRenderbuffer")
+	}
+	return result
+}
+
+func (b *debugBackend) CreateSampler() Sampler {
+	result := b.delegate.CreateSampler()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CreateSampler")
+	}
+	return result
+}
+
+func (b *debugBackend) CreateShader(shaderType GLenum) Shader {
+	result := b.delegate.CreateShader(shaderType)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CreateShader", enumArg(shaderType, EnumCategoryAny))
+	}
+	return result
+}
+
+func (b *debugBackend) CreateTexture() Texture {
+	result := b.delegate.CreateTexture()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CreateTexture")
+	}
+	return result
+}
+
+func (b *debugBackend) CreateVertexArray() VertexArray {
+	result := b.delegate.CreateVertexArray()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CreateVertexArray")
+	}
+	return result
+}
+
+func (b *debugBackend) CullFace(mode GLenum) {
+	b.delegate.CullFace(mode)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "CullFace", enumArg(mode, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) DeleteBuffer(buffer Buffer) {
+	b.delegate.DeleteBuffer(buffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DeleteBuffer", valueArg(buffer))
+	}
+}
+
+func (b *debugBackend) DeleteFramebuffer(framebuffer Framebuffer) {
+	b.delegate.DeleteFramebuffer(framebuffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DeleteFramebuffer", valueArg(framebuffer))
+	}
+}
+
+func (b *debugBackend) DeleteProgram(program Program) {
+	b.delegate.DeleteProgram(program)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DeleteProgram", valueArg(program))
+	}
+}
+
+func (b *debugBackend) DeleteRenderbuffer(renderbuffer Renderbuffer) {
+	b.delegate.DeleteRenderbuffer(renderbuffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DeleteRenderbuffer", valueArg(renderbuffer))
+	}
+}
+
+func (b *debugBackend) DeleteSampler(sampler Sampler) {
+	b.delegate.DeleteSampler(sampler)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DeleteSampler", valueArg(sampler))
+	}
+}
+
+func (b *debugBackend) DeleteShader(shader Shader) {
+	b.delegate.DeleteShader(shader)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DeleteShader", valueArg(shader))
+	}
+}
+
+func (b *debugBackend) DeleteSync(sync Sync) {
+	b.delegate.DeleteSync(sync)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DeleteSync", valueArg(sync))
+	}
+}
+
+func (b *debugBackend) DeleteTexture(texture Texture) {
+	b.delegate.DeleteTexture(texture)
+	delete(b.textureTargets, texture)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DeleteTexture", valueArg(texture))
+	}
+}
+
+func (b *debugBackend) DeleteVertexArray(array VertexArray) {
+	b.delegate.DeleteVertexArray(array)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DeleteVertexArray", valueArg(array))
+	}
+}
+
+func (b *debugBackend) DepthFunc(fn GLenum) {
+	b.delegate.DepthFunc(fn)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DepthFunc", enumArg(fn, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) DepthMask(mask GLboolean) {
+	b.delegate.DepthMask(mask)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DepthMask", valueArg(mask))
+	}
+}
+
+func (b *debugBackend) DepthRange(zNear, zFar GLclampf) {
+	b.delegate.DepthRange(zNear, zFar)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DepthRange", valueArg(zNear), valueArg(zFar))
+	}
+}
+
+func (b *debugBackend) DetachShader(program Program, shader Shader) {
+	b.delegate.DetachShader(program, shader)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DetachShader", valueArg(program), valueArg(shader))
+	}
+}
+
+func (b *debugBackend) Disable(cap GLenum) {
+	b.delegate.Disable(cap)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Disable", enumArg(cap, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) DisableVertexAttribArray(index GLuint) {
+	b.delegate.DisableVertexAttribArray(index)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DisableVertexAttribArray", valueArg(index))
+	}
+}
+
+func (b *debugBackend) DrawArrays(mode GLenum, first GLint, count GLsizei) {
+	b.delegate.DrawArrays(mode, first, count)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawArrays", enumArg(mode, EnumCategoryPrimitive), valueArg(first), valueArg(count))
+	}
+}
+
+func (b *debugBackend) DrawArraysInstanced(mode GLenum, first GLint, count, instanceCount GLsizei) {
+	b.delegate.DrawArraysInstanced(mode, first, count, instanceCount)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawArraysInstanced", enumArg(mode, EnumCategoryPrimitive), valueArg(first), valueArg(count), valueArg(instanceCount))
+	}
+}
+
+func (b *debugBackend) DrawBuffers(buffers []GLenum) {
+	b.delegate.DrawBuffers(buffers)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawBuffers", enumsArg(buffers, EnumCategoryAttachment))
+	}
+}
+
+func (b *debugBackend) DrawElements(mode GLenum, count GLsizei, dtype GLenum, offset GLintptr) {
+	b.delegate.DrawElements(mode, count, dtype, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawElements", enumArg(mode, EnumCategoryPrimitive), valueArg(count), enumArg(dtype, EnumCategoryAny), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) DrawElementsInstanced(mode GLenum, count GLsizei, pType GLenum, offset GLintptr, instanceCount GLsizei) {
+	b.delegate.DrawElementsInstanced(mode, count, pType, offset, instanceCount)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawElementsInstanced", enumArg(mode, EnumCategoryPrimitive), valueArg(count), enumArg(pType, EnumCategoryAny), valueArg(offset), valueArg(instanceCount))
+	}
+}
+
+func (b *debugBackend) DrawingBufferColorSpace() ColorSpace {
+	result := b.delegate.DrawingBufferColorSpace()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawingBufferColorSpace")
+	}
+	return result
+}
+
+func (b *debugBackend) DrawingBufferFormat() GLenum {
+	result := b.delegate.DrawingBufferFormat()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawingBufferFormat")
+	}
+	return result
+}
+
+func (b *debugBackend) DrawingBufferHeight() int {
+	result := b.delegate.DrawingBufferHeight()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawingBufferHeight")
+	}
+	return result
+}
+
+func (b *debugBackend) DrawingBufferStorage(internalFormat GLenum, width, height GLsizei) {
+	b.delegate.DrawingBufferStorage(internalFormat, width, height)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawingBufferStorage", enumArg(internalFormat, EnumCategoryAny), valueArg(width), valueArg(height))
+	}
+}
+
+func (b *debugBackend) DrawingBufferWidth() int {
+	result := b.delegate.DrawingBufferWidth()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "DrawingBufferWidth")
+	}
+	return result
+}
+
+func (b *debugBackend) Enable(cap GLenum) {
+	b.delegate.Enable(cap)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Enable", enumArg(cap, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) EnableVertexAttribArray(index GLuint) {
+	b.delegate.EnableVertexAttribArray(index)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "EnableVertexAttribArray", valueArg(index))
+	}
+}
+
+func (b *debugBackend) Finish() {
+	b.delegate.Finish()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Finish")
+	}
+}
+
+func (b *debugBackend) Flush() {
+	b.delegate.Flush()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Flush")
+	}
+}
+
+func (b *debugBackend) FramebufferRenderbuffer(target, attachment, renderbufferTarget GLenum, renderbuffer Renderbuffer) {
+	b.delegate.FramebufferRenderbuffer(target, attachment, renderbufferTarget, renderbuffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "FramebufferRenderbuffer", enumArg(target, EnumCategoryAny), enumArg(attachment, EnumCategoryAttachment), enumArg(renderbufferTarget, EnumCategoryAny), valueArg(renderbuffer))
+	}
+}
+
+func (b *debugBackend) FramebufferTexture2D(target, attachment, texTarget GLenum, texture Texture, level GLint) {
+	// NOTE: The texture target is validated upfront, since not all
+	// delegates detect a mismatch (e.g. a cube map face of a 2D texture).
+	code := b.checkTexTarget(texTarget, texture)
+	b.delegate.FramebufferTexture2D(target, attachment, texTarget, texture, level)
+	if delegateCode := b.checkError(); code == NO_ERROR {
+		code = delegateCode
+	}
+	if code != NO_ERROR {
+		b.report(code, "FramebufferTexture2D", enumArg(target, EnumCategoryAny), enumArg(attachment, EnumCategoryAttachment), enumArg(texTarget, EnumCategoryAny), valueArg(texture), valueArg(level))
+	}
+}
+
+// checkTexTarget returns the error that FramebufferTexture2D produces for
+// the specified texTarget and texture. A cube map face requires a cube map
+// texture and TEXTURE_2D requires a 2D texture.
+func (b *debugBackend) checkTexTarget(texTarget GLenum, texture Texture) GLenum {
+	isFace := texTarget >= TEXTURE_CUBE_MAP_POSITIVE_X && texTarget <= TEXTURE_CUBE_MAP_NEGATIVE_Z
+	if texTarget != TEXTURE_2D && !isFace {
+		return INVALID_ENUM
+	}
+	bound, ok := b.textureTargets[texture]
+	if !ok {
+		return NO_ERROR
+	}
+	required := GLenum(TEXTURE_2D)
+	if isFace {
+		required = TEXTURE_CUBE_MAP
+	}
+	if bound != required {
+		return INVALID_OPERATION
+	}
+	return NO_ERROR
+}
+
+func (b *debugBackend) FramebufferTextureLayer(target, attachment GLenum, texture Texture, level, layer GLint) {
+	b.delegate.FramebufferTextureLayer(target, attachment, texture, level, layer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "FramebufferTextureLayer", enumArg(target, EnumCategoryAny), enumArg(attachment, EnumCategoryAttachment), valueArg(texture), valueArg(level), valueArg(layer))
+	}
+}
+
+func (b *debugBackend) FrontFace(mode GLenum) {
+	b.delegate.FrontFace(mode)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "FrontFace", enumArg(mode, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) FenceSync(condition GLenum, flags GLbitfield) Sync {
+	result := b.delegate.FenceSync(condition, flags)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "FenceSync", enumArg(condition, EnumCategoryAny), valueArg(flags))
+	}
+	return result
+}
+
+func (b *debugBackend) GenerateMipmap(target GLenum) {
+	b.delegate.GenerateMipmap(target)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GenerateMipmap", enumArg(target, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) GetAttachedShaders(program Program) []Shader {
+	result := b.delegate.GetAttachedShaders(program)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetAttachedShaders", valueArg(program))
+	}
+	return result
+}
+
+func (b *debugBackend) GetAttribLocation(program Program, name string) GLint {
+	result := b.delegate.GetAttribLocation(program, name)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetAttribLocation", valueArg(program), valueArg(name))
+	}
+	return result
+}
+
+func (b *debugBackend) GetBufferSubData(target GLenum, srcOffset GLintptr, data []byte) {
+	b.delegate.GetBufferSubData(target, srcOffset, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetBufferSubData", enumArg(target, EnumCategoryBufferTarget), valueArg(srcOffset), valueArg(data))
+	}
+}
+
+func (b *debugBackend) GetError() GLenum {
+	if len(b.pending) > 0 {
+		code := b.pending[0]
+		b.pending = b.pending[1:]
+		return code
+	}
+	return b.delegate.GetError()
+}
+
+func (b *debugBackend) GetExtension(name string) any {
+	result := b.delegate.GetExtension(name)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetExtension", valueArg(name))
+	}
+	return result
+}
+
+func (b *debugBackend) GetParameter(name GLenum) Any {
+	result := b.delegate.GetParameter(name)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetParameter", enumArg(name, EnumCategoryParameter))
+	}
+	return result
+}
+
+func (b *debugBackend) GetProgramInfoLog(program Program) string {
+	result := b.delegate.GetProgramInfoLog(program)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetProgramInfoLog", valueArg(program))
+	}
+	return result
+}
+
+func (b *debugBackend) GetProgramParameter(program Program, pname GLenum) Any {
+	result := b.delegate.GetProgramParameter(program, pname)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetProgramParameter", valueArg(program), enumArg(pname, EnumCategoryParameter))
+	}
+	return result
+}
+
+func (b *debugBackend) GetSamplerParameter(sampler Sampler, pname GLenum) Any {
+	result := b.delegate.GetSamplerParameter(sampler, pname)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetSamplerParameter", valueArg(sampler), enumArg(pname, EnumCategoryParameter))
+	}
+	return result
+}
+
+func (b *debugBackend) GetShaderInfoLog(shader Shader) string {
+	result := b.delegate.GetShaderInfoLog(shader)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetShaderInfoLog", valueArg(shader))
+	}
+	return result
+}
+
+func (b *debugBackend) GetShaderParameter(shader Shader, pname GLenum) Any {
+	result := b.delegate.GetShaderParameter(shader, pname)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetShaderParameter", valueArg(shader), enumArg(pname, EnumCategoryParameter))
+	}
+	return result
+}
+
+func (b *debugBackend) GetShaderPrecisionFormat(shaderType, precisionType GLenum) ShaderPrecisionFormat {
+	result := b.delegate.GetShaderPrecisionFormat(shaderType, precisionType)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetShaderPrecisionFormat", enumArg(shaderType, EnumCategoryAny), enumArg(precisionType, EnumCategoryAny))
+	}
+	return result
+}
+
+func (b *debugBackend) GetShaderSource(shader Shader) string {
+	result := b.delegate.GetShaderSource(shader)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetShaderSource", valueArg(shader))
+	}
+	return result
+}
+
+func (b *debugBackend) GetSyncParameter(sync Sync, pname GLenum) Any {
+	result := b.delegate.GetSyncParameter(sync, pname)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetSyncParameter", valueArg(sync), enumArg(pname, EnumCategoryParameter))
+	}
+	return result
+}
+
+func (b *debugBackend) GetUniformBlockIndex(program Program, name string) GLuint {
+	result := b.delegate.GetUniformBlockIndex(program, name)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetUniformBlockIndex", valueArg(program), valueArg(name))
+	}
+	return result
+}
+
+func (b *debugBackend) GetUniformLocation(program Program, name string) UniformLocation {
+	result := b.delegate.GetUniformLocation(program, name)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "GetUniformLocation", valueArg(program), valueArg(name))
+	}
+	return result
+}
+
+func (b *debugBackend) Hint(target, mode GLenum) {
+	b.delegate.Hint(target, mode)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Hint", enumArg(target, EnumCategoryAny), enumArg(mode, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) InvalidateFramebuffer(target GLenum, attachments []GLenum) {
+	b.delegate.InvalidateFramebuffer(target, attachments)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "InvalidateFramebuffer", enumArg(target, EnumCategoryAny), enumsArg(attachments, EnumCategoryAttachment))
+	}
+}
+
+func (b *debugBackend) InvalidateSubFramebuffer(target GLenum, attachments []GLenum, x, y GLint, width, height GLsizei) {
+	b.delegate.InvalidateSubFramebuffer(target, attachments, x, y, width, height)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "InvalidateSubFramebuffer", enumArg(target, EnumCategoryAny), enumsArg(attachments, EnumCategoryAttachment), valueArg(x), valueArg(y), valueArg(width), valueArg(height))
+	}
+}
+
+func (b *debugBackend) IsBuffer(buffer Buffer) bool {
+	result := b.delegate.IsBuffer(buffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsBuffer", valueArg(buffer))
+	}
+	return result
+}
+
+func (b *debugBackend) IsContextLost() bool {
+	result := b.delegate.IsContextLost()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsContextLost")
+	}
+	return result
+}
+
+func (b *debugBackend) IsEnabled(cap GLenum) bool {
+	result := b.delegate.IsEnabled(cap)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsEnabled", enumArg(cap, EnumCategoryAny))
+	}
+	return result
+}
+
+func (b *debugBackend) IsFramebuffer(framebuffer Framebuffer) bool {
+	result := b.delegate.IsFramebuffer(framebuffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsFramebuffer", valueArg(framebuffer))
+	}
+	return result
+}
+
+func (b *debugBackend) IsProgram(program Program) bool {
+	result := b.delegate.IsProgram(program)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsProgram", valueArg(program))
+	}
+	return result
+}
+
+func (b *debugBackend) IsQuery(query Query) bool {
+	result := b.delegate.IsQuery(query)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsQuery", valueArg(query))
+	}
+	return result
+}
+
+func (b *debugBackend) IsRenderbuffer(renderbuffer Renderbuffer) bool {
+	result := b.delegate.IsRenderbuffer(renderbuffer)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsRenderbuffer", valueArg(renderbuffer))
+	}
+	return result
+}
+
+func (b *debugBackend) IsSampler(sampler Sampler) bool {
+	result := b.delegate.IsSampler(sampler)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsSampler", valueArg(sampler))
+	}
+	return result
+}
+
+func (b *debugBackend) IsShader(shader Shader) bool {
+	result := b.delegate.IsShader(shader)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsShader", valueArg(shader))
+	}
+	return result
+}
+
+func (b *debugBackend) IsSync(sync Sync) bool {
+	result := b.delegate.IsSync(sync)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsSync", valueArg(sync))
+	}
+	return result
+}
+
+func (b *debugBackend) IsTexture(texture Texture) bool {
+	result := b.delegate.IsTexture(texture)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsTexture", valueArg(texture))
+	}
+	return result
+}
+
+func (b *debugBackend) IsVertexArray(array VertexArray) bool {
+	result := b.delegate.IsVertexArray(array)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "IsVertexArray", valueArg(array))
+	}
+	return result
+}
+
+func (b *debugBackend) LineWidth(width GLfloat) {
+	b.delegate.LineWidth(width)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "LineWidth", valueArg(width))
+	}
+}
+
+func (b *debugBackend) LinkProgram(program Program) {
+	b.delegate.LinkProgram(program)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "LinkProgram", valueArg(program))
+	}
+}
+
+func (b *debugBackend) PixelStorei(pname GLenum, param GLint) {
+	b.delegate.PixelStorei(pname, param)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "PixelStorei", enumArg(pname, EnumCategoryParameter), valueArg(param))
+	}
+}
+
+func (b *debugBackend) PolygonOffset(factor, units GLfloat) {
+	b.delegate.PolygonOffset(factor, units)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "PolygonOffset", valueArg(factor), valueArg(units))
+	}
+}
+
+func (b *debugBackend) ReadBuffer(src GLenum) {
+	b.delegate.ReadBuffer(src)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ReadBuffer", enumArg(src, EnumCategoryAttachment))
+	}
+}
+
+func (b *debugBackend) ReadPixels(x, y GLint, width, height GLsizei, format, dtype GLenum, offset GLintptr) {
+	b.delegate.ReadPixels(x, y, width, height, format, dtype, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ReadPixels", valueArg(x), valueArg(y), valueArg(width), valueArg(height), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) ReadPixelsData(x, y GLint, width, height GLsizei, format, dtype GLenum, data []byte) {
+	b.delegate.ReadPixelsData(x, y, width, height, format, dtype, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ReadPixelsData", valueArg(x), valueArg(y), valueArg(width), valueArg(height), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(data))
+	}
+}
+
+func (b *debugBackend) RenderbufferStorage(target, internalFormat GLenum, width, height GLsizei) {
+	b.delegate.RenderbufferStorage(target, internalFormat, width, height)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "RenderbufferStorage", enumArg(target, EnumCategoryAny), enumArg(internalFormat, EnumCategoryAny), valueArg(width), valueArg(height))
+	}
+}
+
+func (b *debugBackend) RenderbufferStorageMultisample(target GLenum, samples GLsizei, internalFormat GLenum, width, height GLsizei) {
+	b.delegate.RenderbufferStorageMultisample(target, samples, internalFormat, width, height)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "RenderbufferStorageMultisample", enumArg(target, EnumCategoryAny), valueArg(samples), enumArg(internalFormat, EnumCategoryAny), valueArg(width), valueArg(height))
+	}
+}
+
+func (b *debugBackend) SampleCoverage(value GLclampf, invert GLboolean) {
+	b.delegate.SampleCoverage(value, invert)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "SampleCoverage", valueArg(value), valueArg(invert))
+	}
+}
+
+func (b *debugBackend) SamplerParameterf(sampler Sampler, pname GLenum, param GLfloat) {
+	b.delegate.SamplerParameterf(sampler, pname, param)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "SamplerParameterf", valueArg(sampler), enumArg(pname, EnumCategoryParameter), valueArg(param))
+	}
+}
+
+func (b *debugBackend) SamplerParameteri(sampler Sampler, pname GLenum, param GLint) {
+	b.delegate.SamplerParameteri(sampler, pname, param)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "SamplerParameteri", valueArg(sampler), enumArg(pname, EnumCategoryParameter), valueArg(param))
+	}
+}
+
+func (b *debugBackend) Scissor(x, y GLint, width, height GLsizei) {
+	b.delegate.Scissor(x, y, width, height)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Scissor", valueArg(x), valueArg(y), valueArg(width), valueArg(height))
+	}
+}
+
+func (b *debugBackend) SetDrawingBufferColorSpace(colorSpace ColorSpace) {
+	b.delegate.SetDrawingBufferColorSpace(colorSpace)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "SetDrawingBufferColorSpace", valueArg(colorSpace))
+	}
+}
+
+func (b *debugBackend) SetUnpackColorSpace(colorSpace ColorSpace) {
+	b.delegate.SetUnpackColorSpace(colorSpace)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "SetUnpackColorSpace", valueArg(colorSpace))
+	}
+}
+
+func (b *debugBackend) ShaderSource(shader Shader, source string) {
+	b.delegate.ShaderSource(shader, source)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ShaderSource", valueArg(shader), valueArg(source))
+	}
+}
+
+func (b *debugBackend) StencilFunc(fun GLenum, ref GLint, mask GLuint) {
+	b.delegate.StencilFunc(fun, ref, mask)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "StencilFunc", enumArg(fun, EnumCategoryAny), valueArg(ref), valueArg(mask))
+	}
+}
+
+func (b *debugBackend) StencilFuncSeparate(face, fun GLenum, ref GLint, mask GLuint) {
+	b.delegate.StencilFuncSeparate(face, fun, ref, mask)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "StencilFuncSeparate", enumArg(face, EnumCategoryAny), enumArg(fun, EnumCategoryAny), valueArg(ref), valueArg(mask))
+	}
+}
+
+func (b *debugBackend) StencilMask(mask GLuint) {
+	b.delegate.StencilMask(mask)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "StencilMask", valueArg(mask))
+	}
+}
+
+func (b *debugBackend) StencilMaskSeparate(face GLenum, mask GLuint) {
+	b.delegate.StencilMaskSeparate(face, mask)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "StencilMaskSeparate", enumArg(face, EnumCategoryAny), valueArg(mask))
+	}
+}
+
+func (b *debugBackend) StencilOp(fail, zfail, zpass GLenum) {
+	b.delegate.StencilOp(fail, zfail, zpass)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "StencilOp", enumArg(fail, EnumCategoryAny), enumArg(zfail, EnumCategoryAny), enumArg(zpass, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) StencilOpSeparate(face, fail, zfail, zpass GLenum) {
+	b.delegate.StencilOpSeparate(face, fail, zfail, zpass)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "StencilOpSeparate", enumArg(face, EnumCategoryAny), enumArg(fail, EnumCategoryAny), enumArg(zfail, EnumCategoryAny), enumArg(zpass, EnumCategoryAny))
+	}
+}
+
+func (b *debugBackend) TexImage2D(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, data []byte) {
+	b.delegate.TexImage2D(target, level, internalFormat, width, height, border, format, dtype, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexImage2D", enumArg(target, EnumCategoryAny), valueArg(level), enumArg(GLenum(internalFormat), EnumCategoryAny), valueArg(width), valueArg(height), valueArg(border), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(data))
+	}
+}
+
+func (b *debugBackend) TexImage2DOffset(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, offset GLintptr) {
+	b.delegate.TexImage2DOffset(target, level, internalFormat, width, height, border, format, dtype, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexImage2DOffset", enumArg(target, EnumCategoryAny), valueArg(level), enumArg(GLenum(internalFormat), EnumCategoryAny), valueArg(width), valueArg(height), valueArg(border), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) TexImage3D(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, data []byte) {
+	b.delegate.TexImage3D(target, level, internalFormat, width, height, depth, border, format, dtype, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexImage3D", enumArg(target, EnumCategoryAny), valueArg(level), enumArg(GLenum(internalFormat), EnumCategoryAny), valueArg(width), valueArg(height), valueArg(depth), valueArg(border), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(data))
+	}
+}
+
+func (b *debugBackend) TexImage3DOffset(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, offset GLintptr) {
+	b.delegate.TexImage3DOffset(target, level, internalFormat, width, height, depth, border, format, dtype, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexImage3DOffset", enumArg(target, EnumCategoryAny), valueArg(level), enumArg(GLenum(internalFormat), EnumCategoryAny), valueArg(width), valueArg(height), valueArg(depth), valueArg(border), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) TexParameterf(target, pname GLenum, param GLfloat) {
+	b.delegate.TexParameterf(target, pname, param)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexParameterf", enumArg(target, EnumCategoryAny), enumArg(pname, EnumCategoryParameter), valueArg(param))
+	}
+}
+
+func (b *debugBackend) TexStorage2D(target GLenum, levels GLsizei, internalFormat GLenum, width, height GLsizei) {
+	b.delegate.TexStorage2D(target, levels, internalFormat, width, height)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexStorage2D", enumArg(target, EnumCategoryAny), valueArg(levels), enumArg(internalFormat, EnumCategoryAny), valueArg(width), valueArg(height))
+	}
+}
+
+func (b *debugBackend) TexStorage3D(target GLenum, levels GLsizei, internalFormat GLenum, width, height, depth GLsizei) {
+	b.delegate.TexStorage3D(target, levels, internalFormat, width, height, depth)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexStorage3D", enumArg(target, EnumCategoryAny), valueArg(levels), enumArg(internalFormat, EnumCategoryAny), valueArg(width), valueArg(height), valueArg(depth))
+	}
+}
+
+func (b *debugBackend) TexSubImage2D(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format, dtype GLenum, data []byte) {
+	b.delegate.TexSubImage2D(target, level, xoffset, yoffset, width, height, format, dtype, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexSubImage2D", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(width), valueArg(height), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(data))
+	}
+}
+
+func (b *debugBackend) TexSubImage2DOffset(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format, dtype GLenum, offset GLintptr) {
+	b.delegate.TexSubImage2DOffset(target, level, xoffset, yoffset, width, height, format, dtype, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexSubImage2DOffset", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(width), valueArg(height), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) TexSubImage3D(target GLenum, level GLint, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format, dtype GLenum, data []byte) {
+	b.delegate.TexSubImage3D(target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexSubImage3D", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(zoffset), valueArg(width), valueArg(height), valueArg(depth), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(data))
+	}
+}
+
+func (b *debugBackend) TexParameteri(target, pname GLenum, param GLint) {
+	b.delegate.TexParameteri(target, pname, param)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexParameteri", enumArg(target, EnumCategoryAny), enumArg(pname, EnumCategoryParameter), valueArg(param))
+	}
+}
+
+func (b *debugBackend) TexSubImage3DOffset(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format, dtype GLenum, offset GLintptr) {
+	b.delegate.TexSubImage3DOffset(target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "TexSubImage3DOffset", enumArg(target, EnumCategoryAny), valueArg(level), valueArg(xoffset), valueArg(yoffset), valueArg(zoffset), valueArg(width), valueArg(height), valueArg(depth), enumArg(format, EnumCategoryAny), enumArg(dtype, EnumCategoryAny), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) Uniform1f(location UniformLocation, x GLfloat) {
+	b.delegate.Uniform1f(location, x)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Uniform1f", valueArg(location), valueArg(x))
+	}
+}
+
+func (b *debugBackend) Uniform1i(location UniformLocation, x GLint) {
+	b.delegate.Uniform1i(location, x)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Uniform1i", valueArg(location), valueArg(x))
+	}
+}
+
+func (b *debugBackend) Uniform2f(location UniformLocation, x, y GLfloat) {
+	b.delegate.Uniform2f(location, x, y)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Uniform2f", valueArg(location), valueArg(x), valueArg(y))
+	}
+}
+
+func (b *debugBackend) Uniform2i(location UniformLocation, x, y GLint) {
+	b.delegate.Uniform2i(location, x, y)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Uniform2i", valueArg(location), valueArg(x), valueArg(y))
+	}
+}
+
+func (b *debugBackend) Uniform3f(location UniformLocation, x, y, z GLfloat) {
+	b.delegate.Uniform3f(location, x, y, z)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Uniform3f", valueArg(location), valueArg(x), valueArg(y), valueArg(z))
+	}
+}
+
+func (b *debugBackend) Uniform3i(location UniformLocation, x, y, z GLint) {
+	b.delegate.Uniform3i(location, x, y, z)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Uniform3i", valueArg(location), valueArg(x), valueArg(y), valueArg(z))
+	}
+}
+
+func (b *debugBackend) Uniform4f(location UniformLocation, x, y, z, w GLfloat) {
+	b.delegate.Uniform4f(location, x, y, z, w)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Uniform4f", valueArg(location), valueArg(x), valueArg(y), valueArg(z), valueArg(w))
+	}
+}
+
+func (b *debugBackend) Uniform4i(location UniformLocation, x, y, z, w GLint) {
+	b.delegate.Uniform4i(location, x, y, z, w)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Uniform4i", valueArg(location), valueArg(x), valueArg(y), valueArg(z), valueArg(w))
+	}
+}
+
+func (b *debugBackend) UniformBlockBinding(program Program, index, binding GLuint) {
+	b.delegate.UniformBlockBinding(program, index, binding)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "UniformBlockBinding", valueArg(program), valueArg(index), valueArg(binding))
+	}
+}
+
+func (b *debugBackend) UniformMatrix4fv(location UniformLocation, transpose GLboolean, data []GLfloat) {
+	b.delegate.UniformMatrix4fv(location, transpose, data)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "UniformMatrix4fv", valueArg(location), valueArg(transpose), valueArg(data))
+	}
+}
+
+func (b *debugBackend) UnpackColorSpace() ColorSpace {
+	result := b.delegate.UnpackColorSpace()
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "UnpackColorSpace")
+	}
+	return result
+}
+
+func (b *debugBackend) UseProgram(program Program) {
+	b.delegate.UseProgram(program)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "UseProgram", valueArg(program))
+	}
+}
+
+func (b *debugBackend) ValidateProgram(program Program) {
+	b.delegate.ValidateProgram(program)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "ValidateProgram", valueArg(program))
+	}
+}
+
+func (b *debugBackend) VertexAttribIPointer(index GLuint, size GLint, dtype GLenum, stride GLsizei, offset GLintptr) {
+	b.delegate.VertexAttribIPointer(index, size, dtype, stride, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "VertexAttribIPointer", valueArg(index), valueArg(size), enumArg(dtype, EnumCategoryAny), valueArg(stride), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) VertexAttribPointer(index GLuint, size GLint, dtype GLenum, normalized GLboolean, stride GLsizei, offset GLintptr) {
+	b.delegate.VertexAttribPointer(index, size, dtype, normalized, stride, offset)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "VertexAttribPointer", valueArg(index), valueArg(size), enumArg(dtype, EnumCategoryAny), valueArg(normalized), valueArg(stride), valueArg(offset))
+	}
+}
+
+func (b *debugBackend) Viewport(x, y GLint, width, height GLsizei) {
+	b.delegate.Viewport(x, y, width, height)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "Viewport", valueArg(x), valueArg(y), valueArg(width), valueArg(height))
+	}
+}
+
+func (b *debugBackend) WaitSync(sync Sync, flags GLbitfield, timeout GLint64) {
+	b.delegate.WaitSync(sync, flags, timeout)
+	if code := b.checkError(); code != NO_ERROR {
+		b.report(code, "WaitSync", valueArg(sync), valueArg(flags), valueArg(timeout))
+	}
+}
@@ -0,0 +1,183 @@
+package wasmgl_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+	"github.com/mokiat/wasmgl/wasmgltest"
+)
+
+func TestDebugBackend(t *testing.T) {
+	testCases := []struct {
+		name         string
+		run          func(b wasmgl.Backend)
+		wantFunction string
+		wantCode     wasmgl.GLenum
+	}{
+		{
+			name: "valid call",
+			run: func(b wasmgl.Backend) {
+				b.Enable(wasmgl.DEPTH_TEST)
+			},
+			wantCode: wasmgl.NO_ERROR,
+		},
+		{
+			name: "invalid enum",
+			run: func(b wasmgl.Backend) {
+				b.Enable(wasmgl.TEXTURE_2D)
+			},
+			wantFunction: "Enable",
+			wantCode:     wasmgl.INVALID_ENUM,
+		},
+		{
+			name: "invalid operation",
+			run: func(b wasmgl.Backend) {
+				b.BufferData(wasmgl.ARRAY_BUFFER, 16, nil, wasmgl.STATIC_DRAW)
+			},
+			wantFunction: "BufferData",
+			wantCode:     wasmgl.INVALID_OPERATION,
+		},
+		{
+			name: "cube map face of 2D texture",
+			run: func(b wasmgl.Backend) {
+				texture := b.CreateTexture()
+				b.BindTexture(wasmgl.TEXTURE_2D, texture)
+				b.BindFramebuffer(wasmgl.FRAMEBUFFER, b.CreateFramebuffer())
+				b.FramebufferTexture2D(wasmgl.FRAMEBUFFER, wasmgl.COLOR_ATTACHMENT0, wasmgl.TEXTURE_CUBE_MAP_POSITIVE_X, texture, 0)
+			},
+			wantFunction: "FramebufferTexture2D",
+			wantCode:     wasmgl.INVALID_OPERATION,
+		},
+		{
+			name: "2D target of cube map texture",
+			run: func(b wasmgl.Backend) {
+				texture := b.CreateTexture()
+				b.BindTexture(wasmgl.TEXTURE_CUBE_MAP, texture)
+				b.BindFramebuffer(wasmgl.FRAMEBUFFER, b.CreateFramebuffer())
+				b.FramebufferTexture2D(wasmgl.FRAMEBUFFER, wasmgl.COLOR_ATTACHMENT0, wasmgl.TEXTURE_2D, texture, 0)
+			},
+			wantFunction: "FramebufferTexture2D",
+			wantCode:     wasmgl.INVALID_OPERATION,
+		},
+		{
+			name: "cube map face of cube map texture",
+			run: func(b wasmgl.Backend) {
+				texture := b.CreateTexture()
+				b.BindTexture(wasmgl.TEXTURE_CUBE_MAP, texture)
+				b.BindFramebuffer(wasmgl.FRAMEBUFFER, b.CreateFramebuffer())
+				b.FramebufferTexture2D(wasmgl.FRAMEBUFFER, wasmgl.COLOR_ATTACHMENT0, wasmgl.TEXTURE_CUBE_MAP_NEGATIVE_Z, texture, 0)
+			},
+			wantCode: wasmgl.NO_ERROR,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reports []wasmgl.DebugReport
+			b := wasmgl.NewDebugBackend(wasmgltest.NewBackend(), func(report wasmgl.DebugReport) {
+				reports = append(reports, report)
+			})
+			tc.run(b)
+
+			if tc.wantCode == wasmgl.NO_ERROR {
+				if len(reports) != 0 {
+					t.Fatalf("got %d reports, want none", len(reports))
+				}
+			} else {
+				if len(reports) != 1 {
+					t.Fatalf("got %d reports, want 1", len(reports))
+				}
+				report := reports[0]
+				if report.Function != tc.wantFunction {
+					t.Errorf("Function = %q, want %q", report.Function, tc.wantFunction)
+				}
+				if report.Code != tc.wantCode {
+					t.Errorf("Code = %s, want %s", wasmgl.EnumName(report.Code, wasmgl.EnumCategoryError), wasmgl.EnumName(tc.wantCode, wasmgl.EnumCategoryError))
+				}
+				if caller := report.Caller(); !strings.Contains(caller, "debug_test.go") {
+					t.Errorf("Caller() = %q, want location in debug_test.go", caller)
+				}
+			}
+
+			// The detected error needs to remain pending for the caller.
+			if got := b.GetError(); got != tc.wantCode {
+				t.Errorf("GetError() = %s, want %s", wasmgl.EnumName(got, wasmgl.EnumCategoryError), wasmgl.EnumName(tc.wantCode, wasmgl.EnumCategoryError))
+			}
+			if got := b.GetError(); got != wasmgl.NO_ERROR {
+				t.Errorf("second GetError() = %s, want NO_ERROR", wasmgl.EnumName(got, wasmgl.EnumCategoryError))
+			}
+		})
+	}
+}
+
+func TestPanicDebugHandler(t *testing.T) {
+	b := wasmgl.NewDebugBackend(wasmgltest.NewBackend(), nil)
+	defer func() {
+		report, ok := recover().(wasmgl.DebugReport)
+		if !ok {
+			t.Fatalf("expected panic with DebugReport")
+		}
+		if report.Function != "Enable" {
+			t.Errorf("Function = %q, want %q", report.Function, "Enable")
+		}
+	}()
+	b.Enable(wasmgl.TEXTURE_2D)
+}
+
+func TestDebugBackendCheckError(t *testing.T) {
+	useBackend(t, wasmgl.NewDebugBackend(wasmgltest.NewBackend(), func(wasmgl.DebugReport) {}))
+
+	wasmgl.Enable(wasmgl.TEXTURE_2D)
+	if err := wasmgl.CheckError(); !errors.Is(err, wasmgl.ErrInvalidEnum) {
+		t.Errorf("CheckError() = %v, want %v", err, wasmgl.ErrInvalidEnum)
+	}
+}
+
+// lenientBackend does not validate the texture target of
+// FramebufferTexture2D, unlike WebGL.
+type lenientBackend struct {
+	*wasmgltest.Backend
+}
+
+func (lenientBackend) FramebufferTexture2D(target, attachment, texTarget wasmgl.GLenum, texture wasmgl.Texture, level wasmgl.GLint) {
+}
+
+func TestDebugBackendTexTarget(t *testing.T) {
+	testCases := []struct {
+		name       string
+		bindTarget wasmgl.GLenum
+		texTarget  wasmgl.GLenum
+		wantCode   wasmgl.GLenum
+	}{
+		{"2D texture", wasmgl.TEXTURE_2D, wasmgl.TEXTURE_2D, wasmgl.NO_ERROR},
+		{"cube map face", wasmgl.TEXTURE_CUBE_MAP, wasmgl.TEXTURE_CUBE_MAP_POSITIVE_Y, wasmgl.NO_ERROR},
+		{"cube map face of 2D texture", wasmgl.TEXTURE_2D, wasmgl.TEXTURE_CUBE_MAP_POSITIVE_Y, wasmgl.INVALID_OPERATION},
+		{"2D target of cube map", wasmgl.TEXTURE_CUBE_MAP, wasmgl.TEXTURE_2D, wasmgl.INVALID_OPERATION},
+		{"cube map target", wasmgl.TEXTURE_CUBE_MAP, wasmgl.TEXTURE_CUBE_MAP, wasmgl.INVALID_ENUM},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var reports []wasmgl.DebugReport
+			b := wasmgl.NewDebugBackend(lenientBackend{wasmgltest.NewBackend()}, func(report wasmgl.DebugReport) {
+				reports = append(reports, report)
+			})
+			texture := b.CreateTexture()
+			b.BindTexture(tc.bindTarget, texture)
+			b.FramebufferTexture2D(wasmgl.FRAMEBUFFER, wasmgl.COLOR_ATTACHMENT0, tc.texTarget, texture, 0)
+
+			if tc.wantCode == wasmgl.NO_ERROR {
+				if len(reports) != 0 {
+					t.Fatalf("got %d reports, want none", len(reports))
+				}
+				return
+			}
+			if len(reports) != 1 {
+				t.Fatalf("got %d reports, want 1", len(reports))
+			}
+			if code := reports[0].Code; code != tc.wantCode {
+				t.Errorf("Code = %s, want %s", wasmgl.EnumName(code, wasmgl.EnumCategoryError), wasmgl.EnumName(tc.wantCode, wasmgl.EnumCategoryError))
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package wasmgl_test
+
+import (
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+)
+
+func TestEnumName(t *testing.T) {
+	testCases := []struct {
+		value    wasmgl.GLenum
+		category wasmgl.EnumCategory
+		want     string
+	}{
+		{wasmgl.INVALID_OPERATION, wasmgl.EnumCategoryAny, "INVALID_OPERATION"},
+		{wasmgl.TEXTURE_2D, wasmgl.EnumCategoryError, "TEXTURE_2D"},
+		{wasmgl.NO_ERROR, wasmgl.EnumCategoryError, "NO_ERROR"},
+		{wasmgl.NONE, wasmgl.EnumCategoryAttachment, "NONE"},
+		{wasmgl.ZERO, wasmgl.EnumCategoryBlendFactor, "ZERO"},
+		{wasmgl.POINTS, wasmgl.EnumCategoryPrimitive, "POINTS"},
+		{wasmgl.ONE, wasmgl.EnumCategoryBlendFactor, "ONE"},
+		{wasmgl.LINES, wasmgl.EnumCategoryPrimitive, "LINES"},
+		{0x0000, wasmgl.EnumCategoryAny, "POINTS|ZERO|NO_ERROR|NONE"},
+		{wasmgl.FRAMEBUFFER_UNSUPPORTED, wasmgl.EnumCategoryFramebufferStatus, "FRAMEBUFFER_UNSUPPORTED"},
+		{0xFFFF, wasmgl.EnumCategoryAny, "0xFFFF"},
+		{0x12, wasmgl.EnumCategoryError, "0x0012"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := wasmgl.EnumName(tc.value, tc.category); got != tc.want {
+				t.Errorf("EnumName(0x%04X, %d) = %q, want %q", tc.value, tc.category, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package wasmgl
+
+import (
+	"errors"
+	"fmt"
+)
+
+// maxErrorFlags limits the number of times CheckError calls GetError, in
+// case a Backend keeps reporting errors indefinitely.
+const maxErrorFlags = 16
+
+// GLError represents an error code as returned by GetError.
+//
+// GLError values are comparable, so errors.Is can be used to check for
+// specific error codes (e.g. errors.Is(err, ErrInvalidOperation)).
+type GLError GLenum
+
+const (
+	// ErrInvalidEnum indicates that an unacceptable value has been
+	// specified for an enumerated argument.
+	ErrInvalidEnum GLError = INVALID_ENUM
+
+	// ErrInvalidValue indicates that a numeric argument is out of range.
+	ErrInvalidValue GLError = INVALID_VALUE
+
+	// ErrInvalidOperation indicates that the specified command is not
+	// allowed for the current state.
+	ErrInvalidOperation GLError = INVALID_OPERATION
+
+	// ErrInvalidFramebufferOperation indicates that the currently bound
+	// framebuffer is not framebuffer complete when trying to render to or
+	// to read from it.
+	ErrInvalidFramebufferOperation GLError = INVALID_FRAMEBUFFER_OPERATION
+
+	// ErrOutOfMemory indicates that not enough memory is left to execute
+	// the command.
+	ErrOutOfMemory GLError = OUT_OF_MEMORY
+
+	// ErrContextLost indicates that the WebGL context has been lost.
+	ErrContextLost GLError = CONTEXT_LOST_WEBGL
+)
+
+// Error returns the name of the error code.
+func (e GLError) Error() string {
+	return "gl error: " + EnumName(GLenum(e), EnumCategoryError)
+}
+
+// CheckError calls GetError until all error flags have been cleared and
+// returns the errors that were reported as GLError values. If more than one
+// error is reported, then they are combined through errors.Join. The result
+// is nil if there are no errors.
+func CheckError() error {
+	var errs []error
+	for range maxErrorFlags {
+		code := GetError()
+		if code == NO_ERROR {
+			break
+		}
+		errs = append(errs, GLError(code))
+	}
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return errors.Join(errs...)
+	}
+}
+
+// PendingError holds the errors that were already pending when a checked
+// function was called and that were therefore caused by earlier calls.
+//
+// The checked functions return a PendingError joined with the errors of the
+// call itself, so errors.As can be used to tell the two apart. Since a
+// PendingError unwraps to the pending errors, errors.Is matches both.
+type PendingError struct {
+
+	// Function is the name of the checked function.
+	Function string
+
+	// Err holds the pending errors as returned by CheckError.
+	Err error
+}
+
+// Error returns a description of the pending errors.
+func (e *PendingError) Error() string {
+	return fmt.Sprintf("pending before %s: %v", e.Function, e.Err)
+}
+
+// Unwrap returns the pending errors.
+func (e *PendingError) Unwrap() error {
+	return e.Err
+}
+
+// FramebufferStatusError represents a status code as returned by
+// CheckFramebufferStatus for a framebuffer that is not complete.
+//
+// FramebufferStatusError values are comparable, so errors.Is can be used
+// to check for specific status codes.
+type FramebufferStatusError GLenum
+
+const (
+	// ErrFramebufferIncompleteAttachment indicates that an attachment is
+	// not complete.
+	ErrFramebufferIncompleteAttachment FramebufferStatusError = FRAMEBUFFER_INCOMPLETE_ATTACHMENT
+
+	// ErrFramebufferIncompleteMissingAttachment indicates that there are
+	// no attachments.
+	ErrFramebufferIncompleteMissingAttachment FramebufferStatusError = FRAMEBUFFER_INCOMPLETE_MISSING_ATTACHMENT
+
+	// ErrFramebufferIncompleteDimensions indicates that the attachments
+	// do not have the same size.
+	ErrFramebufferIncompleteDimensions FramebufferStatusError = FRAMEBUFFER_INCOMPLETE_DIMENSIONS
+
+	// ErrFramebufferUnsupported indicates that the combination of
+	// attachment formats is not supported.
+	ErrFramebufferUnsupported FramebufferStatusError = FRAMEBUFFER_UNSUPPORTED
+
+	// ErrFramebufferIncompleteMultisample indicates that the attachments
+	// do not have the same number of samples.
+	ErrFramebufferIncompleteMultisample FramebufferStatusError = FRAMEBUFFER_INCOMPLETE_MULTISAMPLE
+)
+
+// Error returns the name of the status code.
+func (e FramebufferStatusError) Error() string {
+	return "framebuffer not complete: " + EnumName(GLenum(e), EnumCategoryFramebufferStatus)
+}
+
+// CheckFramebufferComplete calls CheckFramebufferStatus for the specified
+// target and returns a FramebufferStatusError if the framebuffer is not
+// complete.
+func CheckFramebufferComplete(target GLenum) error {
+	status := CheckFramebufferStatus(target)
+	if status == FRAMEBUFFER_COMPLETE {
+		return nil
+	}
+	return FramebufferStatusError(status)
+}
+
+// FramebufferError describes a framebuffer that is not complete.
+//
+// The Status can be checked through errors.Is (e.g.
+// errors.Is(err, ErrFramebufferIncompleteAttachment)).
+type FramebufferError struct {
+
+	// Framebuffer is the framebuffer that was checked.
+	Framebuffer Framebuffer
+
+	// Status is the status that was returned by CheckFramebufferStatus.
+	Status FramebufferStatusError
+}
+
+// Error returns a description of the framebuffer and its status.
+func (e *FramebufferError) Error() string {
+	return fmt.Sprintf("%s not complete: %s", e.Framebuffer, EnumName(GLenum(e.Status), EnumCategoryFramebufferStatus))
+}
+
+// Unwrap returns the Status of the framebuffer.
+func (e *FramebufferError) Unwrap() error {
+	return e.Status
+}
+
+// CheckFramebuffer calls CheckFramebufferStatus for the specified target
+// and returns a *FramebufferError if the framebuffer is not complete. The
+// specified framebuffer should be the one that is bound to the target and
+// is used to describe the framebuffer (e.g. through its label).
+func CheckFramebuffer(target GLenum, framebuffer Framebuffer) error {
+	status := CheckFramebufferStatus(target)
+	if status == FRAMEBUFFER_COMPLETE {
+		return nil
+	}
+	return &FramebufferError{
+		Framebuffer: framebuffer,
+		Status:      FramebufferStatusError(status),
+	}
+}
+
+// ContextErrorReason describes why a WebGL2 context could not be created.
+type ContextErrorReason int
+
+const (
+	// ContextErrorUnknown indicates that the reason could not be determined.
+	ContextErrorUnknown ContextErrorReason = iota
+
+	// ContextErrorUnsupported indicates that the browser does not support
+	// WebGL2.
+	ContextErrorUnsupported
+
+	// ContextErrorBlocklisted indicates that the browser reported that WebGL
+	// is blocked or disabled (e.g. due to a blocklisted GPU or driver).
+	ContextErrorBlocklisted
+
+	// ContextErrorPerformanceCaveat indicates that a context could only be
+	// created with a major performance caveat (e.g. software rendering) and
+	// the failIfMajorPerformanceCaveat option was set.
+	ContextErrorPerformanceCaveat
+
+	// ContextErrorCanvasInUse indicates that the canvas already holds a
+	// context of a different type (e.g. "2d" or "webgl").
+	ContextErrorCanvasInUse
+)
+
+// String returns a human-readable description of the reason.
+func (r ContextErrorReason) String() string {
+	switch r {
+	case ContextErrorUnsupported:
+		return "webgl2 not supported"
+	case ContextErrorBlocklisted:
+		return "webgl2 blocked or disabled"
+	case ContextErrorPerformanceCaveat:
+		return "major performance caveat"
+	case ContextErrorCanvasInUse:
+		return "canvas holds a different context"
+	default:
+		return "unknown reason"
+	}
+}
+
+var (
+	// ErrContextUnsupported can be used with errors.Is to check whether a
+	// context could not be created because WebGL2 is not supported.
+	ErrContextUnsupported = &ContextError{Reason: ContextErrorUnsupported}
+
+	// ErrContextBlocklisted can be used with errors.Is to check whether a
+	// context could not be created because WebGL2 is blocked or disabled.
+	ErrContextBlocklisted = &ContextError{Reason: ContextErrorBlocklisted}
+
+	// ErrContextPerformanceCaveat can be used with errors.Is to check
+	// whether a context could not be created due to the
+	// failIfMajorPerformanceCaveat option.
+	ErrContextPerformanceCaveat = &ContextError{Reason: ContextErrorPerformanceCaveat}
+
+	// ErrContextCanvasInUse can be used with errors.Is to check whether a
+	// context could not be created because the canvas holds a context of a
+	// different type.
+	ErrContextCanvasInUse = &ContextError{Reason: ContextErrorCanvasInUse}
+)
+
+// ContextError describes why a WebGL2 context could not be created.
+type ContextError struct {
+
+	// Reason is the determined cause of the failure.
+	Reason ContextErrorReason
+
+	// StatusMessage is the message of the webglcontextcreationerror event
+	// that was dispatched by the browser, if any.
+	StatusMessage string
+}
+
+// Error returns a description of the failure.
+func (e *ContextError) Error() string {
+	if e.StatusMessage == "" {
+		return fmt.Sprintf("could not acquire webgl2 context: %s", e.Reason)
+	}
+	return fmt.Sprintf("could not acquire webgl2 context: %s: %s", e.Reason, e.StatusMessage)
+}
+
+// Is returns whether the target is a ContextError with the same Reason,
+// which allows errors.Is to be used with ErrContextUnsupported,
+// ErrContextBlocklisted and ErrContextPerformanceCaveat.
+func (e *ContextError) Is(target error) bool {
+	other, ok := target.(*ContextError)
+	return ok && other.Reason == e.Reason
+}
@@ -0,0 +1,77 @@
+package wasmgl_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+	"github.com/mokiat/wasmgl/wasmgltest"
+)
+
+func TestGLError(t *testing.T) {
+	testCases := []struct {
+		err  wasmgl.GLError
+		want string
+	}{
+		{wasmgl.ErrInvalidEnum, "gl error: INVALID_ENUM"},
+		{wasmgl.ErrInvalidOperation, "gl error: INVALID_OPERATION"},
+		{wasmgl.ErrOutOfMemory, "gl error: OUT_OF_MEMORY"},
+		{wasmgl.ErrContextLost, "gl error: CONTEXT_LOST_WEBGL"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.want, func(t *testing.T) {
+			if got := tc.err.Error(); got != tc.want {
+				t.Errorf("Error() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCheckError(t *testing.T) {
+	testCases := []struct {
+		name string
+		run  func(b *wasmgltest.Backend)
+		want error
+	}{
+		{
+			name: "no error",
+			run:  func(b *wasmgltest.Backend) {},
+			want: nil,
+		},
+		{
+			name: "invalid enum",
+			run: func(b *wasmgltest.Backend) {
+				b.Enable(wasmgl.TEXTURE_2D)
+			},
+			want: wasmgl.ErrInvalidEnum,
+		},
+		{
+			name: "context lost",
+			run: func(b *wasmgltest.Backend) {
+				b.LoseContext()
+			},
+			want: wasmgl.ErrContextLost,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			useBackend(t, b)
+			tc.run(b)
+
+			err := wasmgl.CheckError()
+			if tc.want == nil {
+				if err != nil {
+					t.Fatalf("CheckError() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tc.want) {
+				t.Errorf("CheckError() = %v, want %v", err, tc.want)
+			}
+			if err := wasmgl.CheckError(); err != nil {
+				t.Errorf("second CheckError() = %v, want nil", err)
+			}
+		})
+	}
+}
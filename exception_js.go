@@ -0,0 +1,12 @@
+//go:build js && wasm
+
+package wasmgl
+
+import "syscall/js"
+
+// asException returns the JavaScript exception that is held by the
+// specified recovered panic value.
+func asException(recovered any) (error, bool) {
+	err, ok := recovered.(js.Error)
+	return err, ok
+}
@@ -0,0 +1,10 @@
+//go:build !(js && wasm)
+
+package wasmgl
+
+// asException returns the JavaScript exception that is held by the
+// specified recovered panic value. There are no JavaScript exceptions
+// outside of the browser.
+func asException(recovered any) (error, bool) {
+	return nil, false
+}
@@ -0,0 +1,106 @@
+package wasmgl
+
+// defaultTexelSize is the number of bytes that are assumed for formats that
+// are not known (e.g. compressed formats from extensions).
+const defaultTexelSize = 4
+
+// texelSizes holds the number of bytes that a single texel of the
+// respective internal format occupies.
+//
+// NOTE: Implementations are free to pad formats (e.g. RGB8 to four bytes),
+// so the sizes are only an estimate of the actual GPU memory usage. Padding
+// is assumed for formats where that is almost universally the case.
+var texelSizes = map[GLenum]int{
+	ALPHA:              1,
+	LUMINANCE:          1,
+	LUMINANCE_ALPHA:    2,
+	RGB:                3,
+	RGBA:               4,
+	DEPTH_COMPONENT:    4,
+	DEPTH_STENCIL:      4,
+	R8:                 1,
+	R8_SNORM:           1,
+	R8UI:               1,
+	R8I:                1,
+	R16F:               2,
+	R16UI:              2,
+	R16I:               2,
+	R32F:               4,
+	R32UI:              4,
+	R32I:               4,
+	RG8:                2,
+	RG8_SNORM:          2,
+	RG8UI:              2,
+	RG8I:               2,
+	RG16F:              4,
+	RG16UI:             4,
+	RG16I:              4,
+	RG32F:              8,
+	RG32UI:             8,
+	RG32I:              8,
+	RGB8:               3,
+	SRGB8:              3,
+	RGB8_SNORM:         3,
+	RGB8UI:             3,
+	RGB8I:              3,
+	RGB565:             2,
+	R11F_G11F_B10F:     4,
+	RGB9_E5:            4,
+	RGB16F:             6,
+	RGB16UI:            6,
+	RGB16I:             6,
+	RGB32F:             12,
+	RGB32UI:            12,
+	RGB32I:             12,
+	RGBA8:              4,
+	SRGB8_ALPHA8:       4,
+	RGBA8_SNORM:        4,
+	RGBA8UI:            4,
+	RGBA8I:             4,
+	RGB5_A1:            2,
+	RGBA4:              2,
+	RGB10_A2:           4,
+	RGB10_A2UI:         4,
+	RGBA16F:            8,
+	RGBA16UI:           8,
+	RGBA16I:            8,
+	RGBA32F:            16,
+	RGBA32UI:           16,
+	RGBA32I:            16,
+	DEPTH_COMPONENT16:  2,
+	DEPTH_COMPONENT24:  4,
+	DEPTH_COMPONENT32F: 4,
+	DEPTH24_STENCIL8:   4,
+	DEPTH32F_STENCIL8:  8,
+	STENCIL_INDEX8:     1,
+}
+
+// texelSize returns the estimated number of bytes that a single texel of
+// the specified internal format occupies.
+func texelSize(internalFormat GLenum) int {
+	if size, ok := texelSizes[internalFormat]; ok {
+		return size
+	}
+	return defaultTexelSize
+}
+
+// textureStorageSize returns the estimated number of bytes that are needed
+// for the specified number of mipmap levels of a texture with the specified
+// target, format and base level dimensions.
+func textureStorageSize(target GLenum, levels GLsizei, internalFormat GLenum, width, height, depth GLsizei) int {
+	faces := 1
+	if target == TEXTURE_CUBE_MAP {
+		faces = 6
+	}
+	texel := texelSize(internalFormat)
+	total := 0
+	for range levels {
+		total += faces * int(width) * int(height) * int(depth) * texel
+		width = max(width/2, 1)
+		height = max(height/2, 1)
+		if target == TEXTURE_3D {
+			depth = max(depth/2, 1)
+		}
+	}
+	return total
+}
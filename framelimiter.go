@@ -0,0 +1,80 @@
+package wasmgl
+
+import "fmt"
+
+// NewFrameLimiter returns a FrameLimiter that keeps track of up to the
+// specified number of frames in flight.
+func NewFrameLimiter(framesInFlight int) *FrameLimiter {
+	if framesInFlight < 1 {
+		panic(fmt.Errorf("frames in flight must be positive but was %d", framesInFlight))
+	}
+	return &FrameLimiter{
+		fences: make([]Sync, framesInFlight),
+	}
+}
+
+// FrameLimiter uses fences to track which of the last N frames have been
+// completed by the GPU. It is meant to be used together with dynamic
+// buffers that are split into N regions and written to in a ring-buffered
+// fashion, where each frame writes to the region of its slot.
+//
+// A typical frame looks as follows:
+//
+//	slot, ready := limiter.Begin()
+//	if ready {
+//		// update the region of the dynamic buffer for slot
+//	}
+//	// issue draw calls
+//	limiter.End()
+type FrameLimiter struct {
+	fences []Sync
+	slot   int
+}
+
+// FramesInFlight returns the number of slots that this FrameLimiter cycles
+// through.
+func (l *FrameLimiter) FramesInFlight() int {
+	return len(l.fences)
+}
+
+// Begin returns the slot that the current frame should use and whether the
+// GPU has finished with the commands of the last frame that used that slot.
+// If ready is false, then the region of the slot must not be overwritten
+// during this frame.
+//
+// Begin never blocks, since WebGL does not allow waiting for the GPU.
+func (l *FrameLimiter) Begin() (slot int, ready bool) {
+	fence := l.fences[l.slot]
+	if !fence.IsValid() {
+		return l.slot, true
+	}
+	switch ClientWaitSync(fence, 0, 0) {
+	case ALREADY_SIGNALED, CONDITION_SATISFIED:
+		DeleteSync(fence)
+		l.fences[l.slot] = NilSync
+		return l.slot, true
+	default:
+		return l.slot, false
+	}
+}
+
+// End inserts a fence after the commands of the current frame and advances
+// to the next slot.
+func (l *FrameLimiter) End() {
+	if fence := l.fences[l.slot]; fence.IsValid() {
+		DeleteSync(fence)
+	}
+	l.fences[l.slot] = FenceSync(SYNC_GPU_COMMANDS_COMPLETE, 0)
+	l.slot = (l.slot + 1) % len(l.fences)
+}
+
+// Delete releases all fences that are still pending.
+func (l *FrameLimiter) Delete() {
+	for i, fence := range l.fences {
+		if fence.IsValid() {
+			DeleteSync(fence)
+		}
+		l.fences[i] = NilSync
+	}
+	l.slot = 0
+}
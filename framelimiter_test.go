@@ -0,0 +1,84 @@
+package wasmgl_test
+
+import (
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+	"github.com/mokiat/wasmgl/wasmgltest"
+)
+
+func TestFrameLimiter(t *testing.T) {
+	type frame struct {
+		signal    bool
+		wantSlot  int
+		wantReady bool
+	}
+	testCases := []struct {
+		name           string
+		framesInFlight int
+		frames         []frame
+	}{
+		{
+			name:           "single slot signaled",
+			framesInFlight: 1,
+			frames: []frame{
+				{signal: false, wantSlot: 0, wantReady: true},
+				{signal: true, wantSlot: 0, wantReady: true},
+			},
+		},
+		{
+			name:           "single slot pending",
+			framesInFlight: 1,
+			frames: []frame{
+				{signal: false, wantSlot: 0, wantReady: true},
+				{signal: false, wantSlot: 0, wantReady: false},
+			},
+		},
+		{
+			name:           "multiple slots",
+			framesInFlight: 3,
+			frames: []frame{
+				{signal: false, wantSlot: 0, wantReady: true},
+				{signal: false, wantSlot: 1, wantReady: true},
+				{signal: false, wantSlot: 2, wantReady: true},
+				{signal: false, wantSlot: 0, wantReady: false},
+				{signal: true, wantSlot: 1, wantReady: true},
+				{signal: false, wantSlot: 2, wantReady: true},
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			b.SetManualFences(true)
+			useBackend(t, b)
+
+			limiter := wasmgl.NewFrameLimiter(tc.framesInFlight)
+			for i, f := range tc.frames {
+				if f.signal {
+					b.SignalFences()
+				}
+				slot, ready := limiter.Begin()
+				if slot != f.wantSlot || ready != f.wantReady {
+					t.Errorf("frame %d: Begin() = (%d, %t), want (%d, %t)", i, slot, ready, f.wantSlot, f.wantReady)
+				}
+				limiter.End()
+			}
+			limiter.Delete()
+
+			b.ExpectNoError(t)
+			if live := b.LiveObjects(); live != 0 {
+				t.Errorf("got %d live fences after Delete, want 0", live)
+			}
+		})
+	}
+}
+
+func TestNewFrameLimiterInvalid(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for zero frames in flight")
+		}
+	}()
+	wasmgl.NewFrameLimiter(0)
+}
@@ -1,730 +1,697 @@
-//go:build js && wasm
-
 package wasmgl
 
-import (
-	"fmt"
-	"syscall/js"
-)
-
-var (
-	// WebGL1 functions:
-	// 	- https://www.khronos.org/registry/webgl/specs/latest/1.0/
-	// 	- https://developer.mozilla.org/en-US/docs/Web/API/WebGLRenderingContext
-
-	// WebGL2 functions
-	// 	- https://www.khronos.org/registry/webgl/specs/latest/2.0/
-	// 	- https://developer.mozilla.org/en-US/docs/Web/API/WebGL2RenderingContext
-
-	// NOTE: We use references to JS functions and Invoke instead of
-	// Call since the latter leads to strings being passed around
-	// and TextDecoder being used on JS side.
-
-	fnActiveTexture            js.Value
-	fnAttachShader             js.Value
-	fnBindBuffer               js.Value
-	fnBindBufferBase           js.Value
-	fnBindBufferRange          js.Value
-	fnBindFramebuffer          js.Value
-	fnBindSampler              js.Value
-	fnBindTexture              js.Value
-	fnBindVertexArray          js.Value
-	fnBlendColor               js.Value
-	fnBlendEquationSeparate    js.Value
-	fnBlendFunc                js.Value
-	fnBlendFuncSeparate        js.Value
-	fnBlitFramebuffer          js.Value
-	fnBufferData               js.Value
-	fnBufferSubData            js.Value
-	fnCheckFramebufferStatus   js.Value
-	fnClear                    js.Value
-	fnClearBufferfv            js.Value
-	fnClearBufferiv            js.Value
-	fnClearBufferuiv           js.Value
-	fnClearBufferfi            js.Value
-	fnClearColor               js.Value
-	fnClearDepth               js.Value
-	fnClearStencil             js.Value
-	fnClientWaitSync           js.Value
-	fnColorMask                js.Value
-	fnCompileShader            js.Value
-	fnCopyTexSubImage2D        js.Value
-	fnCreateBuffer             js.Value
-	fnCreateFramebuffer        js.Value
-	fnCreateProgram            js.Value
-	fnCreateSampler            js.Value
-	fnCreateShader             js.Value
-	fnCreateTexture            js.Value
-	fnCreateVertexArray        js.Value
-	fnCullFace                 js.Value
-	fnDeleteBuffer             js.Value
-	fnDeleteFramebuffer        js.Value
-	fnDeleteProgram            js.Value
-	fnDeleteSampler            js.Value
-	fnDeleteShader             js.Value
-	fnDeleteSync               js.Value
-	fnDeleteTexture            js.Value
-	fnDeleteVertexArray        js.Value
-	fnDepthFunc                js.Value
-	fnDepthMask                js.Value
-	fnDetachShader             js.Value
-	fnDisable                  js.Value
-	fnDisableVertexAttribArray js.Value
-	fnDrawArrays               js.Value
-	fnDrawArraysInstanced      js.Value
-	fnDrawBuffers              js.Value
-	fnDrawElements             js.Value
-	fnDrawElementsInstanced    js.Value
-	fnEnable                   js.Value
-	fnEnableVertexAttribArray  js.Value
-	fnFinish                   js.Value
-	fnFlush                    js.Value
-	fnFramebufferTexture2D     js.Value
-	fnFramebufferTextureLayer  js.Value
-	fnFrontFace                js.Value
-	fnFenceSync                js.Value
-	fnGenerateMipmap           js.Value
-	fnGetAttribLocation        js.Value
-	fnGetBufferSubData         js.Value
-	fnGetError                 js.Value
-	fnGetExtension             js.Value
-	fnGetParameter             js.Value
-	fnGetProgramInfoLog        js.Value
-	fnGetProgramParameter      js.Value
-	fnGetSamplerParameter      js.Value
-	fnGetShaderInfoLog         js.Value
-	fnGetShaderParameter       js.Value
-	fnGetSyncParameter         js.Value
-	fnGetUniformBlockIndex     js.Value
-	fnGetUniformLocation       js.Value
-	fnInvalidateFramebuffer    js.Value
-	fnIsSampler                js.Value
-	fnLineWidth                js.Value
-	fnLinkProgram              js.Value
-	fnPolygonOffset            js.Value
-	fnReadPixels               js.Value
-	fnSamplerParameterf        js.Value
-	fnSamplerParameteri        js.Value
-	fnScissor                  js.Value
-	fnShaderSource             js.Value
-	fnStencilFuncSeparate      js.Value
-	fnStencilMaskSeparate      js.Value
-	fnStencilOpSeparate        js.Value
-	fnTexImage2D               js.Value
-	fnTexStorage2D             js.Value
-	fnTexStorage3D             js.Value
-	fnTexSubImage2D            js.Value
-	fnTexSubImage3D            js.Value
-	fnTexParameteri            js.Value
-	fnUniform1f                js.Value
-	fnUniform1i                js.Value
-	fnUniform2f                js.Value
-	fnUniform2i                js.Value
-	fnUniform3f                js.Value
-	fnUniform3i                js.Value
-	fnUniform4f                js.Value
-	fnUniform4i                js.Value
-	fnUniformBlockBinding      js.Value
-	fnUniformMatrix4fv         js.Value
-	fnUseProgram               js.Value
-	fnVertexAttribIPointer     js.Value
-	fnVertexAttribPointer      js.Value
-	fnViewport                 js.Value
-)
-
-func initFunctions(gl js.Value) {
-	fnActiveTexture = getFunction(gl, "activeTexture")
-	fnAttachShader = getFunction(gl, "attachShader")
-	fnBindBuffer = getFunction(gl, "bindBuffer")
-	fnBindBufferBase = getFunction(gl, "bindBufferBase")
-	fnBindBufferRange = getFunction(gl, "bindBufferRange")
-	fnBindFramebuffer = getFunction(gl, "bindFramebuffer")
-	fnBindSampler = getFunction(gl, "bindSampler")
-	fnBindTexture = getFunction(gl, "bindTexture")
-	fnBindVertexArray = getFunction(gl, "bindVertexArray")
-	fnBlendColor = getFunction(gl, "blendColor")
-	fnBlendEquationSeparate = getFunction(gl, "blendEquationSeparate")
-	fnBlendFunc = getFunction(gl, "blendFunc")
-	fnBlendFuncSeparate = getFunction(gl, "blendFuncSeparate")
-	fnBlitFramebuffer = getFunction(gl, "blitFramebuffer")
-	fnBufferData = getFunction(gl, "bufferData")
-	fnBufferSubData = getFunction(gl, "bufferSubData")
-	fnCheckFramebufferStatus = getFunction(gl, "checkFramebufferStatus")
-	fnClear = getFunction(gl, "clear")
-	fnClearBufferfv = getFunction(gl, "clearBufferfv")
-	fnClearBufferiv = getFunction(gl, "clearBufferiv")
-	fnClearBufferuiv = getFunction(gl, "clearBufferuiv")
-	fnClearBufferfi = getFunction(gl, "clearBufferfi")
-	fnClearColor = getFunction(gl, "clearColor")
-	fnClearDepth = getFunction(gl, "clearDepth")
-	fnClearStencil = getFunction(gl, "clearStencil")
-	fnClientWaitSync = getFunction(gl, "clientWaitSync")
-	fnColorMask = getFunction(gl, "colorMask")
-	fnCompileShader = getFunction(gl, "compileShader")
-	fnCopyTexSubImage2D = getFunction(gl, "copyTexSubImage2D")
-	fnCreateBuffer = getFunction(gl, "createBuffer")
-	fnCreateFramebuffer = getFunction(gl, "createFramebuffer")
-	fnCreateProgram = getFunction(gl, "createProgram")
-	fnCreateSampler = getFunction(gl, "createSampler")
-	fnCreateShader = getFunction(gl, "createShader")
-	fnCreateTexture = getFunction(gl, "createTexture")
-	fnCreateVertexArray = getFunction(gl, "createVertexArray")
-	fnCullFace = getFunction(gl, "cullFace")
-	fnDeleteBuffer = getFunction(gl, "deleteBuffer")
-	fnDeleteFramebuffer = getFunction(gl, "deleteFramebuffer")
-	fnDeleteProgram = getFunction(gl, "deleteProgram")
-	fnDeleteSampler = getFunction(gl, "deleteSampler")
-	fnDeleteShader = getFunction(gl, "deleteShader")
-	fnDeleteSync = getFunction(gl, "deleteSync")
-	fnDeleteTexture = getFunction(gl, "deleteTexture")
-	fnDeleteVertexArray = getFunction(gl, "deleteVertexArray")
-	fnDepthFunc = getFunction(gl, "depthFunc")
-	fnDepthMask = getFunction(gl, "depthMask")
-	fnDetachShader = getFunction(gl, "detachShader")
-	fnDisable = getFunction(gl, "disable")
-	fnDisableVertexAttribArray = getFunction(gl, "disableVertexAttribArray")
-	fnDrawArrays = getFunction(gl, "drawArrays")
-	fnDrawArraysInstanced = getFunction(gl, "drawArraysInstanced")
-	fnDrawBuffers = getFunction(gl, "drawBuffers")
-	fnDrawElements = getFunction(gl, "drawElements")
-	fnDrawElementsInstanced = getFunction(gl, "drawElementsInstanced")
-	fnEnable = getFunction(gl, "enable")
-	fnEnableVertexAttribArray = getFunction(gl, "enableVertexAttribArray")
-	fnFinish = getFunction(gl, "finish")
-	fnFlush = getFunction(gl, "flush")
-	fnFramebufferTexture2D = getFunction(gl, "framebufferTexture2D")
-	fnFramebufferTextureLayer = getFunction(gl, "framebufferTextureLayer")
-	fnFrontFace = getFunction(gl, "frontFace")
-	fnFenceSync = getFunction(gl, "fenceSync")
-	fnGenerateMipmap = getFunction(gl, "generateMipmap")
-	fnGetAttribLocation = getFunction(gl, "getAttribLocation")
-	fnGetBufferSubData = getFunction(gl, "getBufferSubData")
-	fnGetError = getFunction(gl, "getError")
-	fnGetExtension = getFunction(gl, "getExtension")
-	fnGetParameter = getFunction(gl, "getParameter")
-	fnGetProgramInfoLog = getFunction(gl, "getProgramInfoLog")
-	fnGetProgramParameter = getFunction(gl, "getProgramParameter")
-	fnGetSamplerParameter = getFunction(gl, "getSamplerParameter")
-	fnGetShaderInfoLog = getFunction(gl, "getShaderInfoLog")
-	fnGetShaderParameter = getFunction(gl, "getShaderParameter")
-	fnGetSyncParameter = getFunction(gl, "getSyncParameter")
-	fnGetUniformBlockIndex = getFunction(gl, "getUniformBlockIndex")
-	fnGetUniformLocation = getFunction(gl, "getUniformLocation")
-	fnInvalidateFramebuffer = getFunction(gl, "invalidateFramebuffer")
-	fnIsSampler = getFunction(gl, "isSampler")
-	fnLineWidth = getFunction(gl, "lineWidth")
-	fnLinkProgram = getFunction(gl, "linkProgram")
-	fnPolygonOffset = getFunction(gl, "polygonOffset")
-	fnReadPixels = getFunction(gl, "readPixels")
-	fnSamplerParameterf = getFunction(gl, "samplerParameterf")
-	fnSamplerParameteri = getFunction(gl, "samplerParameteri")
-	fnScissor = getFunction(gl, "scissor")
-	fnShaderSource = getFunction(gl, "shaderSource")
-	fnStencilFuncSeparate = getFunction(gl, "stencilFuncSeparate")
-	fnStencilMaskSeparate = getFunction(gl, "stencilMaskSeparate")
-	fnStencilOpSeparate = getFunction(gl, "stencilOpSeparate")
-	fnTexImage2D = getFunction(gl, "texImage2D")
-	fnTexStorage2D = getFunction(gl, "texStorage2D")
-	fnTexStorage3D = getFunction(gl, "texStorage3D")
-	fnTexSubImage2D = getFunction(gl, "texSubImage2D")
-	fnTexSubImage3D = getFunction(gl, "texSubImage3D")
-	fnTexParameteri = getFunction(gl, "texParameteri")
-	fnUniform1f = getFunction(gl, "uniform1f")
-	fnUniform1i = getFunction(gl, "uniform1i")
-	fnUniform2f = getFunction(gl, "uniform2f")
-	fnUniform2i = getFunction(gl, "uniform2i")
-	fnUniform3f = getFunction(gl, "uniform3f")
-	fnUniform3i = getFunction(gl, "uniform3i")
-	fnUniform4f = getFunction(gl, "uniform4f")
-	fnUniform4i = getFunction(gl, "uniform4i")
-	fnUniformBlockBinding = getFunction(gl, "uniformBlockBinding")
-	fnUniformMatrix4fv = getFunction(gl, "uniformMatrix4fv")
-	fnUseProgram = getFunction(gl, "useProgram")
-	fnVertexAttribIPointer = getFunction(gl, "vertexAttribIPointer")
-	fnVertexAttribPointer = getFunction(gl, "vertexAttribPointer")
-	fnViewport = getFunction(gl, "viewport")
-}
+import "runtime"
 
 func ActiveTexture(texture GLenum) {
-	fnActiveTexture.Invoke(texture)
+	backend.ActiveTexture(texture)
 }
 
 func AttachShader(program Program, shader Shader) {
-	fnAttachShader.Invoke(js.Value(program), js.Value(shader))
+	backend.AttachShader(program, shader)
 }
 
 func BindBuffer(target GLenum, buffer Buffer) {
-	fnBindBuffer.Invoke(target, js.Value(buffer))
+	backend.BindBuffer(target, buffer)
 }
 
 func BindBufferBase(target GLenum, index GLuint, buffer Buffer) {
-	fnBindBufferBase.Invoke(target, index, js.Value(buffer))
+	backend.BindBufferBase(target, index, buffer)
 }
 
 func BindBufferRange(target GLenum, index GLuint, buffer Buffer, offset GLintptr, size GLsizeiptr) {
-	fnBindBufferRange.Invoke(target, index, js.Value(buffer), offset, size)
+	backend.BindBufferRange(target, index, buffer, offset, size)
 }
 
 func BindFramebuffer(target GLenum, framebuffer Framebuffer) {
-	fnBindFramebuffer.Invoke(target, js.Value(framebuffer))
+	backend.BindFramebuffer(target, framebuffer)
+}
+
+func BindRenderbuffer(target GLenum, renderbuffer Renderbuffer) {
+	backend.BindRenderbuffer(target, renderbuffer)
 }
 
 func BindSampler(unit GLuint, sampler Sampler) {
-	fnBindSampler.Invoke(unit, js.Value(sampler))
+	backend.BindSampler(unit, sampler)
 }
 
 func BindTexture(target GLenum, texture Texture) {
-	fnBindTexture.Invoke(target, js.Value(texture))
+	backend.BindTexture(target, texture)
 }
 
 func BindVertexArray(array VertexArray) {
-	fnBindVertexArray.Invoke(js.Value(array))
+	backend.BindVertexArray(array)
 }
 
 func BlendColor(red, green, blue, alpha GLclampf) {
-	fnBlendColor.Invoke(red, green, blue, alpha)
+	backend.BlendColor(red, green, blue, alpha)
+}
+
+func BlendEquation(mode GLenum) {
+	backend.BlendEquation(mode)
 }
 
 func BlendEquationSeparate(modeRGB, modeAlpha GLenum) {
-	fnBlendEquationSeparate.Invoke(modeRGB, modeAlpha)
+	backend.BlendEquationSeparate(modeRGB, modeAlpha)
 }
 
 func BlendFunc(sfactor, dfactor GLenum) {
-	fnBlendFunc.Invoke(sfactor, dfactor)
+	backend.BlendFunc(sfactor, dfactor)
 }
 
 func BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha GLenum) {
-	fnBlendFuncSeparate.Invoke(srcRGB, dstRGB, srcAlpha, dstAlpha)
+	backend.BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha)
 }
 
 func BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1 GLint, mask GLbitfield, filter GLenum) {
-	fnBlitFramebuffer.Invoke(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1, mask, filter)
+	backend.BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1, mask, filter)
 }
 
 func BufferData(target GLenum, size GLsizeiptr, data []byte, usage GLenum) {
-	if data != nil {
-		pushBufferData(data)
-		fnBufferData.Invoke(target, uint8Array, usage, 0, len(data))
-	} else {
-		fnBufferData.Invoke(target, size, usage)
-	}
+	backend.BufferData(target, size, data, usage)
 }
 
 func BufferSubData(target GLenum, dstOffset GLintptr, data []byte) {
-	pushBufferData(data)
-	fnBufferSubData.Invoke(target, dstOffset, uint8Array, 0, len(data))
+	backend.BufferSubData(target, dstOffset, data)
 }
 
 func CheckFramebufferStatus(target GLenum) GLenum {
-	return GLenum(fnCheckFramebufferStatus.Invoke(target).Int())
+	return backend.CheckFramebufferStatus(target)
 }
 
 func Clear(mask GLbitfield) {
-	fnClear.Invoke(mask)
+	backend.Clear(mask)
 }
 
 func ClearBufferfv(buffer GLenum, drawBuffer GLint, values Float32List) {
-	pushBufferData(values)
-	fnClearBufferfv.Invoke(buffer, drawBuffer, float32Array)
+	backend.ClearBufferfv(buffer, drawBuffer, values)
+}
+
+func ClearBufferfvOffset(buffer GLenum, drawBuffer GLint, values Float32List, srcOffset GLuint) {
+	backend.ClearBufferfvOffset(buffer, drawBuffer, values, srcOffset)
 }
 
 func ClearBufferiv(buffer GLenum, drawBuffer GLint, values Int32List) {
-	pushBufferData(values)
-	fnClearBufferiv.Invoke(buffer, drawBuffer, int32Array)
+	backend.ClearBufferiv(buffer, drawBuffer, values)
+}
+
+func ClearBufferivOffset(buffer GLenum, drawBuffer GLint, values Int32List, srcOffset GLuint) {
+	backend.ClearBufferivOffset(buffer, drawBuffer, values, srcOffset)
 }
 
 func ClearBufferuiv(buffer GLenum, drawBuffer GLint, values Uint32List) {
-	pushBufferData(values)
-	fnClearBufferuiv.Invoke(buffer, drawBuffer, uint32Array)
+	backend.ClearBufferuiv(buffer, drawBuffer, values)
+}
+
+func ClearBufferuivOffset(buffer GLenum, drawBuffer GLint, values Uint32List, srcOffset GLuint) {
+	backend.ClearBufferuivOffset(buffer, drawBuffer, values, srcOffset)
 }
 
 func ClearBufferfi(buffer GLenum, drawBuffer GLint, depth GLfloat, stencil GLint) {
-	fnClearBufferfi.Invoke(buffer, drawBuffer, depth, stencil)
+	backend.ClearBufferfi(buffer, drawBuffer, depth, stencil)
 }
 
 func ClearColor(r, g, b, a GLclampf) {
-	fnClearColor.Invoke(r, g, b, a)
+	backend.ClearColor(r, g, b, a)
 }
 
 func ClearDepth(depth GLclampf) {
-	fnClearDepth.Invoke(depth)
+	backend.ClearDepth(depth)
 }
 
 func ClearStencil(stencil GLint) {
-	fnClearStencil.Invoke(stencil)
+	backend.ClearStencil(stencil)
 }
 
 func ClientWaitSync(sync Sync, flags GLbitfield, timeout GLuint64) GLenum {
-	return GLenum(fnClientWaitSync.Invoke(js.Value(sync), flags, timeout).Int())
+	return backend.ClientWaitSync(sync, flags, timeout)
 }
 
 func ColorMask(r, g, b, a GLboolean) {
-	fnColorMask.Invoke(r, g, b, a)
+	backend.ColorMask(r, g, b, a)
 }
 
 func CompileShader(shader Shader) {
-	fnCompileShader.Invoke(js.Value(shader))
+	backend.CompileShader(shader)
+}
+
+func CompressedTexImage2D(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, data []byte) {
+	backend.CompressedTexImage2D(target, level, internalFormat, width, height, border, data)
+}
+
+func CompressedTexImage2DOffset(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, imageSize GLsizei, offset GLintptr) {
+	backend.CompressedTexImage2DOffset(target, level, internalFormat, width, height, border, imageSize, offset)
+}
+
+func CompressedTexImage3D(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, data []byte) {
+	backend.CompressedTexImage3D(target, level, internalFormat, width, height, depth, border, data)
+}
+
+func CompressedTexImage3DOffset(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, imageSize GLsizei, offset GLintptr) {
+	backend.CompressedTexImage3DOffset(target, level, internalFormat, width, height, depth, border, imageSize, offset)
+}
+
+func CompressedTexSubImage2D(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format GLenum, data []byte) {
+	backend.CompressedTexSubImage2D(target, level, xoffset, yoffset, width, height, format, data)
+}
+
+func CompressedTexSubImage2DOffset(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format GLenum, imageSize GLsizei, offset GLintptr) {
+	backend.CompressedTexSubImage2DOffset(target, level, xoffset, yoffset, width, height, format, imageSize, offset)
+}
+
+func CompressedTexSubImage3D(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format GLenum, data []byte) {
+	backend.CompressedTexSubImage3D(target, level, xoffset, yoffset, zoffset, width, height, depth, format, data)
+}
+
+func CompressedTexSubImage3DOffset(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format GLenum, imageSize GLsizei, offset GLintptr) {
+	backend.CompressedTexSubImage3DOffset(target, level, xoffset, yoffset, zoffset, width, height, depth, format, imageSize, offset)
+}
+
+func CopyBufferSubData(readTarget, writeTarget GLenum, readOffset, writeOffset GLintptr, size GLsizeiptr) {
+	backend.CopyBufferSubData(readTarget, writeTarget, readOffset, writeOffset, size)
+}
+
+func CopyTexImage2D(target GLenum, level GLint, internalFormat GLenum, x, y GLint, width, height GLsizei, border GLint) {
+	backend.CopyTexImage2D(target, level, internalFormat, x, y, width, height, border)
 }
 
 func CopyTexSubImage2D(target GLenum, level, xoffset, yoffset, x, y GLint, width, height GLsizei) {
-	fnCopyTexSubImage2D.Invoke(target, level, xoffset, yoffset, x, y, width, height)
+	backend.CopyTexSubImage2D(target, level, xoffset, yoffset, x, y, width, height)
+}
+
+func CopyTexSubImage3D(target GLenum, level, xoffset, yoffset, zoffset, x, y GLint, width, height GLsizei) {
+	backend.CopyTexSubImage3D(target, level, xoffset, yoffset, zoffset, x, y, width, height)
 }
 
 func CreateBuffer() Buffer {
-	return Buffer(fnCreateBuffer.Invoke())
+	return backend.CreateBuffer()
 }
 
 func CreateFramebuffer() Framebuffer {
-	return Framebuffer(fnCreateFramebuffer.Invoke())
+	return backend.CreateFramebuffer()
 }
 
 func CreateProgram() Program {
-	return Program(fnCreateProgram.Invoke())
+	return backend.CreateProgram()
+}
+
+func CreateRenderbuffer() Renderbuffer {
+	return backend.CreateRenderbuffer()
 }
 
 func CreateSampler() Sampler {
-	return Sampler(fnCreateSampler.Invoke())
+	return backend.CreateSampler()
 }
 
 func CreateShader(shaderType GLenum) Shader {
-	return Shader(fnCreateShader.Invoke(shaderType))
+	return backend.CreateShader(shaderType)
 }
 
 func CreateTexture() Texture {
-	return Texture(fnCreateTexture.Invoke())
+	return backend.CreateTexture()
 }
 
 func CreateVertexArray() VertexArray {
-	return VertexArray(fnCreateVertexArray.Invoke())
+	return backend.CreateVertexArray()
 }
 
 func CullFace(mode GLenum) {
-	fnCullFace.Invoke(mode)
+	backend.CullFace(mode)
 }
 
 func DeleteBuffer(buffer Buffer) {
-	fnDeleteBuffer.Invoke(js.Value(buffer))
+	backend.DeleteBuffer(buffer)
+	buffer.obj.release()
 }
 
 func DeleteFramebuffer(framebuffer Framebuffer) {
-	fnDeleteFramebuffer.Invoke(js.Value(framebuffer))
+	backend.DeleteFramebuffer(framebuffer)
+	framebuffer.obj.release()
 }
 
 func DeleteProgram(program Program) {
-	fnDeleteProgram.Invoke(js.Value(program))
+	backend.DeleteProgram(program)
+	program.obj.release()
+}
+
+func DeleteRenderbuffer(renderbuffer Renderbuffer) {
+	backend.DeleteRenderbuffer(renderbuffer)
+	renderbuffer.obj.release()
 }
 
 func DeleteSampler(sampler Sampler) {
-	fnDeleteSampler.Invoke(js.Value(sampler))
+	backend.DeleteSampler(sampler)
+	sampler.obj.release()
 }
 
 func DeleteShader(shader Shader) {
-	fnDeleteShader.Invoke(js.Value(shader))
+	backend.DeleteShader(shader)
+	shader.obj.release()
 }
 
 func DeleteSync(sync Sync) {
-	fnDeleteSync.Invoke(js.Value(sync))
+	backend.DeleteSync(sync)
+	sync.obj.release()
 }
 
 func DeleteTexture(texture Texture) {
-	fnDeleteTexture.Invoke(js.Value(texture))
+	backend.DeleteTexture(texture)
+	texture.obj.release()
 }
 
 func DeleteVertexArray(array VertexArray) {
-	fnDeleteVertexArray.Invoke(js.Value(array))
+	backend.DeleteVertexArray(array)
+	array.obj.release()
 }
 
 func DepthFunc(fn GLenum) {
-	fnDepthFunc.Invoke(fn)
+	backend.DepthFunc(fn)
 }
 
 func DepthMask(mask GLboolean) {
-	fnDepthMask.Invoke(mask)
+	backend.DepthMask(mask)
+}
+
+func DepthRange(zNear, zFar GLclampf) {
+	backend.DepthRange(zNear, zFar)
 }
 
 func DetachShader(program Program, shader Shader) {
-	fnDetachShader.Invoke(js.Value(program), js.Value(shader))
+	backend.DetachShader(program, shader)
 }
 
 func Disable(cap GLenum) {
-	fnDisable.Invoke(cap)
+	backend.Disable(cap)
 }
 
 func DisableVertexAttribArray(index GLuint) {
-	fnDisableVertexAttribArray.Invoke(index)
+	backend.DisableVertexAttribArray(index)
 }
 
 func DrawArrays(mode GLenum, first GLint, count GLsizei) {
-	fnDrawArrays.Invoke(mode, first, count)
+	backend.DrawArrays(mode, first, count)
 }
 
 func DrawArraysInstanced(mode GLenum, first GLint, count, instanceCount GLsizei) {
-	fnDrawArraysInstanced.Invoke(mode, first, count, instanceCount)
+	backend.DrawArraysInstanced(mode, first, count, instanceCount)
 }
 
 func DrawBuffers(buffers []GLenum) {
-	ensureSliceSize(len(buffers))
-	view := pushSliceData(buffers, 0)
-	fnDrawBuffers.Invoke(view)
+	backend.DrawBuffers(buffers)
 }
 
 func DrawElements(mode GLenum, count GLsizei, dtype GLenum, offset GLintptr) {
-	fnDrawElements.Invoke(mode, count, dtype, offset)
+	backend.DrawElements(mode, count, dtype, offset)
 }
 
 func DrawElementsInstanced(mode GLenum, count GLsizei, pType GLenum, offset GLintptr, instanceCount GLsizei) {
-	fnDrawElementsInstanced.Invoke(mode, count, pType, offset, instanceCount)
+	backend.DrawElementsInstanced(mode, count, pType, offset, instanceCount)
+}
+
+func DrawingBufferColorSpace() ColorSpace {
+	return backend.DrawingBufferColorSpace()
+}
+
+func DrawingBufferFormat() GLenum {
+	return backend.DrawingBufferFormat()
 }
 
 func DrawingBufferHeight() int {
-	return context.Get("drawingBufferHeight").Int()
+	return backend.DrawingBufferHeight()
+}
+
+func DrawingBufferStorage(internalFormat GLenum, width, height GLsizei) {
+	backend.DrawingBufferStorage(internalFormat, width, height)
 }
 
 func DrawingBufferWidth() int {
-	return context.Get("drawingBufferWidth").Int()
+	return backend.DrawingBufferWidth()
 }
 
 func Enable(cap GLenum) {
-	fnEnable.Invoke(cap)
+	backend.Enable(cap)
 }
 
 func EnableVertexAttribArray(index GLuint) {
-	fnEnableVertexAttribArray.Invoke(index)
+	backend.EnableVertexAttribArray(index)
 }
 
 func Finish() {
-	fnFinish.Invoke()
+	backend.Finish()
 }
 
 func Flush() {
-	fnFlush.Invoke()
+	backend.Flush()
+}
+
+func FramebufferRenderbuffer(target, attachment, renderbufferTarget GLenum, renderbuffer Renderbuffer) {
+	backend.FramebufferRenderbuffer(target, attachment, renderbufferTarget, renderbuffer)
 }
 
 func FramebufferTexture2D(target, attachment, texTarget GLenum, texture Texture, level GLint) {
-	fnFramebufferTexture2D.Invoke(target, attachment, texTarget, js.Value(texture), level)
+	backend.FramebufferTexture2D(target, attachment, texTarget, texture, level)
 }
 
 func FramebufferTextureLayer(target, attachment GLenum, texture Texture, level, layer GLint) {
-	fnFramebufferTextureLayer.Invoke(target, attachment, js.Value(texture), level, layer)
+	backend.FramebufferTextureLayer(target, attachment, texture, level, layer)
 }
 
 func FrontFace(mode GLenum) {
-	fnFrontFace.Invoke(mode)
+	backend.FrontFace(mode)
 }
 
 func FenceSync(condition GLenum, flags GLbitfield) Sync {
-	return Sync(fnFenceSync.Invoke(condition, flags))
+	return backend.FenceSync(condition, flags)
 }
 
 func GenerateMipmap(target GLenum) {
-	fnGenerateMipmap.Invoke(target)
+	backend.GenerateMipmap(target)
+}
+
+func GetAttachedShaders(program Program) []Shader {
+	return backend.GetAttachedShaders(program)
 }
 
 func GetAttribLocation(program Program, name string) GLint {
-	return GLint(fnGetAttribLocation.Invoke(js.Value(program), name).Int())
+	return backend.GetAttribLocation(program, name)
 }
 
 func GetBufferSubData[T DataTypes](target GLenum, srcOffset GLintptr, data []T) {
-	length := byteSize(data)
-	ensureBufferSize(length)
-	fnGetBufferSubData.Invoke(target, 0, uint8Array, 0, length)
-	popBufferData(data)
+	backend.GetBufferSubData(target, srcOffset, asByteSlice(data))
+	runtime.KeepAlive(data)
 }
 
 func GetError() GLenum {
-	return GLenum(fnGetError.Invoke().Int())
+	return backend.GetError()
 }
 
 func GetExtension(name string) any {
-	result := fnGetExtension.Invoke(name)
-	if result.IsNull() {
-		return nil
-	}
-	// We return plain true at this point in time but in the future it might
-	// be possible to return a specific extension struct here. This is why
-	// the result type has been left as any.
-	return true
+	return backend.GetExtension(name)
 }
 
 func GetParameter(name GLenum) Any {
-	return Any(fnGetParameter.Invoke(name))
+	return backend.GetParameter(name)
 }
 
 func GetProgramInfoLog(program Program) string {
-	return fnGetProgramInfoLog.Invoke(js.Value(program)).String()
+	return backend.GetProgramInfoLog(program)
 }
 
 func GetProgramParameter(program Program, pname GLenum) Any {
-	return Any(fnGetProgramParameter.Invoke(js.Value(program), pname))
+	return backend.GetProgramParameter(program, pname)
 }
 
 func GetSamplerParameter(sampler Sampler, pname GLenum) Any {
-	return Any(fnGetSamplerParameter.Invoke(js.Value(sampler), pname))
+	return backend.GetSamplerParameter(sampler, pname)
 }
 
 func GetShaderInfoLog(shader Shader) string {
-	return fnGetShaderInfoLog.Invoke(js.Value(shader)).String()
+	return backend.GetShaderInfoLog(shader)
 }
 
 func GetShaderParameter(shader Shader, pname GLenum) Any {
-	return Any(fnGetShaderParameter.Invoke(js.Value(shader), pname))
+	return backend.GetShaderParameter(shader, pname)
+}
+
+func GetShaderPrecisionFormat(shaderType, precisionType GLenum) ShaderPrecisionFormat {
+	return backend.GetShaderPrecisionFormat(shaderType, precisionType)
+}
+
+func GetShaderSource(shader Shader) string {
+	return backend.GetShaderSource(shader)
 }
 
 func GetSyncParameter(sync Sync, pname GLenum) Any {
-	return Any(fnGetSyncParameter.Invoke(js.Value(sync), pname))
+	return backend.GetSyncParameter(sync, pname)
 }
 
 func GetUniformBlockIndex(program Program, name string) GLuint {
-	return GLuint(fnGetUniformBlockIndex.Invoke(js.Value(program), name).Int())
+	return backend.GetUniformBlockIndex(program, name)
 }
 
 func GetUniformLocation(program Program, name string) UniformLocation {
-	return UniformLocation(fnGetUniformLocation.Invoke(js.Value(program), name))
+	return backend.GetUniformLocation(program, name)
+}
+
+func Hint(target, mode GLenum) {
+	backend.Hint(target, mode)
 }
 
 func InvalidateFramebuffer(target GLenum, attachments []GLenum) {
-	ensureSliceSize(len(attachments))
-	view := pushSliceData(attachments, 0)
-	fnInvalidateFramebuffer.Invoke(target, view)
+	backend.InvalidateFramebuffer(target, attachments)
+}
+
+func InvalidateSubFramebuffer(target GLenum, attachments []GLenum, x, y GLint, width, height GLsizei) {
+	backend.InvalidateSubFramebuffer(target, attachments, x, y, width, height)
+}
+
+func IsBuffer(buffer Buffer) bool {
+	return backend.IsBuffer(buffer)
+}
+
+func IsContextLost() bool {
+	return backend.IsContextLost()
+}
+
+func IsEnabled(cap GLenum) bool {
+	return backend.IsEnabled(cap)
+}
+
+func IsFramebuffer(framebuffer Framebuffer) bool {
+	return backend.IsFramebuffer(framebuffer)
+}
+
+func IsProgram(program Program) bool {
+	return backend.IsProgram(program)
+}
+
+func IsQuery(query Query) bool {
+	return backend.IsQuery(query)
+}
+
+func IsRenderbuffer(renderbuffer Renderbuffer) bool {
+	return backend.IsRenderbuffer(renderbuffer)
 }
 
 func IsSampler(sampler Sampler) bool {
-	return fnIsSampler.Invoke(js.Value(sampler)).Bool()
+	return backend.IsSampler(sampler)
+}
+
+func IsShader(shader Shader) bool {
+	return backend.IsShader(shader)
+}
+
+func IsSync(sync Sync) bool {
+	return backend.IsSync(sync)
+}
+
+func IsTexture(texture Texture) bool {
+	return backend.IsTexture(texture)
+}
+
+func IsVertexArray(array VertexArray) bool {
+	return backend.IsVertexArray(array)
 }
 
 func LineWidth(width GLfloat) {
-	fnLineWidth.Invoke(width)
+	backend.LineWidth(width)
 }
 
 func LinkProgram(program Program) {
-	fnLinkProgram.Invoke(js.Value(program))
+	backend.LinkProgram(program)
+}
+
+func PixelStorei(pname GLenum, param GLint) {
+	backend.PixelStorei(pname, param)
 }
 
 func PolygonOffset(factor, units GLfloat) {
-	fnPolygonOffset.Invoke(factor, units)
+	backend.PolygonOffset(factor, units)
+}
+
+func ReadBuffer(src GLenum) {
+	backend.ReadBuffer(src)
 }
 
 func ReadPixels(x, y GLint, width, height GLsizei, format, dtype GLenum, offset GLintptr) {
-	fnReadPixels.Invoke(x, y, width, height, format, dtype, offset)
+	backend.ReadPixels(x, y, width, height, format, dtype, offset)
+}
+
+func ReadPixelsData[T DataTypes](x, y GLint, width, height GLsizei, format, dtype GLenum, data []T) {
+	backend.ReadPixelsData(x, y, width, height, format, dtype, asByteSlice(data))
+}
+
+func RenderbufferStorage(target, internalFormat GLenum, width, height GLsizei) {
+	backend.RenderbufferStorage(target, internalFormat, width, height)
+}
+
+func RenderbufferStorageMultisample(target GLenum, samples GLsizei, internalFormat GLenum, width, height GLsizei) {
+	backend.RenderbufferStorageMultisample(target, samples, internalFormat, width, height)
+}
+
+func SampleCoverage(value GLclampf, invert GLboolean) {
+	backend.SampleCoverage(value, invert)
 }
 
 func SamplerParameterf(sampler Sampler, pname GLenum, param GLfloat) {
-	fnSamplerParameterf.Invoke(js.Value(sampler), pname, param)
+	backend.SamplerParameterf(sampler, pname, param)
 }
 
 func SamplerParameteri(sampler Sampler, pname GLenum, param GLint) {
-	fnSamplerParameteri.Invoke(js.Value(sampler), pname, param)
+	backend.SamplerParameteri(sampler, pname, param)
 }
 
 func Scissor(x, y GLint, width, height GLsizei) {
-	fnScissor.Invoke(x, y, width, height)
+	backend.Scissor(x, y, width, height)
+}
+
+func SetDrawingBufferColorSpace(colorSpace ColorSpace) {
+	backend.SetDrawingBufferColorSpace(colorSpace)
+}
+
+func SetUnpackColorSpace(colorSpace ColorSpace) {
+	backend.SetUnpackColorSpace(colorSpace)
 }
 
 func ShaderSource(shader Shader, source string) {
-	fnShaderSource.Invoke(js.Value(shader), source)
+	backend.ShaderSource(shader, source)
+}
+
+func StencilFunc(fun GLenum, ref GLint, mask GLuint) {
+	backend.StencilFunc(fun, ref, mask)
 }
 
 func StencilFuncSeparate(face, fun GLenum, ref GLint, mask GLuint) {
-	fnStencilFuncSeparate.Invoke(face, fun, ref, mask)
+	backend.StencilFuncSeparate(face, fun, ref, mask)
+}
+
+func StencilMask(mask GLuint) {
+	backend.StencilMask(mask)
 }
 
 func StencilMaskSeparate(face GLenum, mask GLuint) {
-	fnStencilMaskSeparate.Invoke(face, mask)
+	backend.StencilMaskSeparate(face, mask)
+}
+
+func StencilOp(fail, zfail, zpass GLenum) {
+	backend.StencilOp(fail, zfail, zpass)
 }
 
 func StencilOpSeparate(face, fail, zfail, zpass GLenum) {
-	fnStencilOpSeparate.Invoke(face, fail, zfail, zpass)
+	backend.StencilOpSeparate(face, fail, zfail, zpass)
 }
 
 func TexImage2D(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, data []byte) {
-	pushBufferData(data)
-	fnTexImage2D.Invoke(target, level, internalFormat, width, height, border, format, dtype, uint8Array, 0)
+	backend.TexImage2D(target, level, internalFormat, width, height, border, format, dtype, data)
+}
+
+func TexImage2DOffset(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, offset GLintptr) {
+	backend.TexImage2DOffset(target, level, internalFormat, width, height, border, format, dtype, offset)
+}
+
+func TexImage3D(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, data []byte) {
+	backend.TexImage3D(target, level, internalFormat, width, height, depth, border, format, dtype, data)
+}
+
+func TexImage3DOffset(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, offset GLintptr) {
+	backend.TexImage3DOffset(target, level, internalFormat, width, height, depth, border, format, dtype, offset)
+}
+
+func TexParameterf(target, pname GLenum, param GLfloat) {
+	backend.TexParameterf(target, pname, param)
 }
 
 func TexStorage2D(target GLenum, levels GLsizei, internalFormat GLenum, width, height GLsizei) {
-	fnTexStorage2D.Invoke(target, levels, internalFormat, width, height)
+	backend.TexStorage2D(target, levels, internalFormat, width, height)
 }
 
 func TexStorage3D(target GLenum, levels GLsizei, internalFormat GLenum, width, height, depth GLsizei) {
-	fnTexStorage3D.Invoke(target, levels, internalFormat, width, height, depth)
+	backend.TexStorage3D(target, levels, internalFormat, width, height, depth)
 }
 
 func TexSubImage2D(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format, dtype GLenum, data []byte) {
-	pushBufferData(data)
-	switch dtype {
-	case UNSIGNED_BYTE:
-		fnTexSubImage2D.Invoke(target, level, xoffset, yoffset, width, height, format, dtype, uint8Array, 0)
-	case HALF_FLOAT:
-		fnTexSubImage2D.Invoke(target, level, xoffset, yoffset, width, height, format, dtype, uint16Array, 0)
-	case FLOAT:
-		fnTexSubImage2D.Invoke(target, level, xoffset, yoffset, width, height, format, dtype, float32Array, 0)
-	default:
-		panic(fmt.Errorf("unsupported dtype: %d", dtype))
-	}
+	backend.TexSubImage2D(target, level, xoffset, yoffset, width, height, format, dtype, data)
+}
+
+func TexSubImage2DOffset(target GLenum, level, xoffset, yoffset GLint, width, height GLsizei, format, dtype GLenum, offset GLintptr) {
+	backend.TexSubImage2DOffset(target, level, xoffset, yoffset, width, height, format, dtype, offset)
 }
 
 func TexSubImage3D(target GLenum, level GLint, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format, dtype GLenum, data []byte) {
-	pushBufferData(data)
-	fnTexSubImage3D.Invoke(target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, uint8Array, 0)
+	backend.TexSubImage3D(target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, data)
 }
 
 func TexParameteri(target, pname GLenum, param GLint) {
-	fnTexParameteri.Invoke(target, pname, param)
+	backend.TexParameteri(target, pname, param)
+}
+
+func TexSubImage3DOffset(target GLenum, level, xoffset, yoffset, zoffset GLint, width, height, depth GLsizei, format, dtype GLenum, offset GLintptr) {
+	backend.TexSubImage3DOffset(target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, offset)
 }
 
 func Uniform1f(location UniformLocation, x GLfloat) {
-	fnUniform1f.Invoke(js.Value(location), x)
+	backend.Uniform1f(location, x)
 }
 
 func Uniform1i(location UniformLocation, x GLint) {
-	fnUniform1i.Invoke(js.Value(location), x)
+	backend.Uniform1i(location, x)
 }
 
 func Uniform2f(location UniformLocation, x, y GLfloat) {
-	fnUniform2f.Invoke(js.Value(location), x, y)
+	backend.Uniform2f(location, x, y)
 }
 
 func Uniform2i(location UniformLocation, x, y GLint) {
-	fnUniform2i.Invoke(js.Value(location), x, y)
+	backend.Uniform2i(location, x, y)
 }
 
 func Uniform3f(location UniformLocation, x, y, z GLfloat) {
-	fnUniform3f.Invoke(js.Value(location), x, y, z)
+	backend.Uniform3f(location, x, y, z)
 }
 
 func Uniform3i(location UniformLocation, x, y, z GLint) {
-	fnUniform3i.Invoke(js.Value(location), x, y, z)
+	backend.Uniform3i(location, x, y, z)
 }
 
 func Uniform4f(location UniformLocation, x, y, z, w GLfloat) {
-	fnUniform4f.Invoke(js.Value(location), x, y, z, w)
+	backend.Uniform4f(location, x, y, z, w)
 }
 
 func Uniform4i(location UniformLocation, x, y, z, w GLint) {
-	fnUniform4i.Invoke(js.Value(location), x, y, z, w)
+	backend.Uniform4i(location, x, y, z, w)
 }
 
 func UniformBlockBinding(program Program, index, binding GLuint) {
-	fnUniformBlockBinding.Invoke(js.Value(program), index, binding)
+	backend.UniformBlockBinding(program, index, binding)
 }
 
 func UniformMatrix4fv(location UniformLocation, transpose GLboolean, data []GLfloat) {
-	pushBufferData(data)
-	fnUniformMatrix4fv.Invoke(js.Value(location), transpose, float32Array, 0, len(data))
+	backend.UniformMatrix4fv(location, transpose, data)
+}
+
+func UnpackColorSpace() ColorSpace {
+	return backend.UnpackColorSpace()
 }
 
 func UseProgram(program Program) {
-	fnUseProgram.Invoke(js.Value(program))
+	backend.UseProgram(program)
+}
+
+func ValidateProgram(program Program) {
+	backend.ValidateProgram(program)
 }
 
 func VertexAttribIPointer(index GLuint, size GLint, dtype GLenum, stride GLsizei, offset GLintptr) {
-	fnVertexAttribIPointer.Invoke(index, size, dtype, stride, offset)
+	backend.VertexAttribIPointer(index, size, dtype, stride, offset)
 }
 
 func VertexAttribPointer(index GLuint, size GLint, dtype GLenum, normalized GLboolean, stride GLsizei, offset GLintptr) {
-	fnVertexAttribPointer.Invoke(index, size, dtype, normalized, stride, offset)
+	backend.VertexAttribPointer(index, size, dtype, normalized, stride, offset)
 }
 
 func Viewport(x, y GLint, width, height GLsizei) {
-	fnViewport.Invoke(x, y, width, height)
+	backend.Viewport(x, y, width, height)
+}
+
+func WaitSync(sync Sync, flags GLbitfield, timeout GLint64) {
+	backend.WaitSync(sync, flags, timeout)
 }
@@ -0,0 +1,38 @@
+//go:build js && wasm
+
+package wasmgl
+
+import "syscall/js"
+
+var (
+	// identities maps JavaScript objects to numeric identities, without
+	// preventing them from being garbage collected.
+	identities js.Value
+
+	lastIdentity float64
+)
+
+// jsIdentity is the identity key of a JavaScript object.
+type jsIdentity float64
+
+// identityKey returns a map key that is the same for all values that refer
+// to the same underlying object.
+func identityKey(value any) (any, bool) {
+	v, ok := value.(js.Value)
+	if !ok {
+		return comparableKey(value)
+	}
+	if v.Type() != js.TypeObject {
+		return nil, false
+	}
+	if identities.IsUndefined() {
+		identities = js.Global().Get("WeakMap").New()
+	}
+	id := identities.Call("get", v)
+	if id.IsUndefined() {
+		lastIdentity++
+		id = js.ValueOf(lastIdentity)
+		identities.Call("set", v, id)
+	}
+	return jsIdentity(id.Float()), true
+}
@@ -0,0 +1,9 @@
+//go:build !(js && wasm)
+
+package wasmgl
+
+// identityKey returns a map key that is the same for all values that refer
+// to the same underlying object.
+func identityKey(value any) (any, bool) {
+	return comparableKey(value)
+}
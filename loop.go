@@ -0,0 +1,68 @@
+package wasmgl
+
+import "time"
+
+const (
+	// maxUpdateSteps limits the number of fixed updates per frame, so that
+	// a slow update does not cause an ever growing backlog.
+	maxUpdateSteps = 8
+
+	// statsWindow is the number of recent frames that LoopStats covers.
+	statsWindow = 120
+)
+
+// fixedSteps divides the time that passes between frames into fixed update
+// steps.
+type fixedSteps struct {
+	step        time.Duration
+	accumulated time.Duration
+}
+
+// advance accumulates the specified time and returns the number of fixed
+// steps that are due, as well as the fraction (in the range [0, 1)) of a
+// step that remains. At most maxUpdateSteps are returned, in which case the
+// remaining backlog of whole steps is dropped. No steps are returned if the
+// step is not positive.
+func (s *fixedSteps) advance(delta time.Duration) (int, float64) {
+	if s.step <= 0 {
+		return 0, 0
+	}
+	s.accumulated += delta
+	steps := int(s.accumulated / s.step)
+	if steps > maxUpdateSteps {
+		steps = maxUpdateSteps
+		s.accumulated %= s.step
+	} else {
+		s.accumulated -= time.Duration(steps) * s.step
+	}
+	return steps, float64(s.accumulated) / float64(s.step)
+}
+
+// frameTimes keeps the times of the most recent frames (up to statsWindow)
+// in a ring buffer.
+type frameTimes struct {
+	times [statsWindow]time.Duration
+	count int
+}
+
+// add records the time of a frame, replacing the oldest recorded time once
+// the window is full.
+func (f *frameTimes) add(frameTime time.Duration) {
+	f.times[f.count%statsWindow] = frameTime
+	f.count++
+}
+
+// summary returns the average and the worst of the recorded frame times,
+// which are zero if no frame time has been recorded.
+func (f *frameTimes) summary() (average, worst time.Duration) {
+	count := min(f.count, statsWindow)
+	if count == 0 {
+		return 0, 0
+	}
+	var total time.Duration
+	for _, frameTime := range f.times[:count] {
+		total += frameTime
+		worst = max(worst, frameTime)
+	}
+	return total / time.Duration(count), worst
+}
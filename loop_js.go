@@ -0,0 +1,254 @@
+//go:build js && wasm
+
+package wasmgl
+
+import (
+	"sync"
+	"syscall/js"
+	"time"
+)
+
+// Frame holds information about the frame that is being rendered.
+type Frame struct {
+
+	// Index is the sequence number of the frame, starting from zero.
+	Index uint64
+
+	// Time is the time at which the frame started, relative to the start
+	// of the Loop. Time does not advance while the Loop is paused.
+	Time time.Duration
+
+	// Delta is the time that has passed since the previous frame.
+	Delta time.Duration
+
+	// Interpolation is the fraction (in the range [0, 1)) of a fixed update
+	// step that has accumulated but not been processed yet. It can be used
+	// to interpolate between the last two update states. It is zero if no
+	// fixed update is configured.
+	Interpolation float64
+}
+
+// LoopStats holds timing statistics about the recent frames of a Loop.
+type LoopStats struct {
+
+	// Frames is the total number of frames that have been rendered.
+	Frames uint64
+
+	// AverageFrameTime is the average time between recent frames.
+	AverageFrameTime time.Duration
+
+	// WorstFrameTime is the longest time between recent frames.
+	WorstFrameTime time.Duration
+}
+
+// LoopOption represents a configuration option for a Loop.
+type LoopOption func(l *Loop)
+
+// WithLoopFixedUpdate configures a function that is called with a fixed
+// time step, zero or more times per frame before the frame is rendered,
+// depending on how much time has accumulated.
+func WithLoopFixedUpdate(step time.Duration, update func(step time.Duration)) LoopOption {
+	return func(l *Loop) {
+		l.fixed = fixedSteps{step: step}
+		l.update = update
+	}
+}
+
+// WithLoopPauseWhenHidden configures whether the Loop pauses while the page
+// is hidden (e.g. a background tab). This is enabled by default and has no
+// effect inside workers, where there is no document.
+func WithLoopPauseWhenHidden(pause bool) LoopOption {
+	return func(l *Loop) {
+		l.pauseWhenHidden = pause
+	}
+}
+
+// WithLoopSurface configures a Surface that is updated at the start of
+// each frame, before the fixed updates and rendering.
+func WithLoopSurface(surface *Surface) LoopOption {
+	return func(l *Loop) {
+		l.surface = surface
+	}
+}
+
+// NewLoop returns a Loop that calls the specified render function once per
+// animation frame. The Loop needs to be started through Start.
+func NewLoop(render func(frame Frame), opts ...LoopOption) *Loop {
+	l := &Loop{
+		render:          render,
+		pauseWhenHidden: true,
+		done:            make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+// Loop drives rendering through requestAnimationFrame.
+//
+// The Loop keeps the Go program alive only as long as the caller waits for
+// it, which is typically done by blocking on Done at the end of main.
+type Loop struct {
+	render          func(frame Frame)
+	update          func(step time.Duration)
+	pauseWhenHidden bool
+	surface         *Surface
+
+	frameCallback      js.Func
+	visibilityCallback js.Func
+	requestID          js.Value
+
+	running  bool
+	paused   bool
+	stopped  bool
+	doneOnce sync.Once
+	done     chan struct{}
+
+	index      uint64
+	time       time.Duration
+	lastStamp  float64
+	hasStamp   bool
+	fixed      fixedSteps
+	frameTimes frameTimes
+}
+
+// Start starts requesting animation frames. Calling Start on a Loop that
+// has been started already has no effect.
+func (l *Loop) Start() {
+	if l.running || l.stopped {
+		return
+	}
+	l.running = true
+	l.frameCallback = js.FuncOf(func(this js.Value, args []js.Value) any {
+		l.onFrame(args[0].Float())
+		return nil
+	})
+	if htmlDocument := js.Global().Get("document"); l.pauseWhenHidden && !htmlDocument.IsUndefined() {
+		l.visibilityCallback = js.FuncOf(func(this js.Value, args []js.Value) any {
+			if htmlDocument.Get("hidden").Bool() {
+				l.Pause()
+			} else {
+				l.Resume()
+			}
+			return nil
+		})
+		htmlDocument.Call("addEventListener", "visibilitychange", l.visibilityCallback)
+	}
+	l.requestFrame()
+}
+
+// Pause stops rendering until Resume is called. The time of the Loop
+// does not advance while it is paused.
+func (l *Loop) Pause() {
+	if !l.running || l.paused {
+		return
+	}
+	l.paused = true
+	l.cancelFrame()
+}
+
+// Resume continues rendering after Pause.
+func (l *Loop) Resume() {
+	if !l.running || !l.paused {
+		return
+	}
+	l.paused = false
+	l.hasStamp = false
+	l.requestFrame()
+}
+
+// Paused returns whether the Loop is paused.
+func (l *Loop) Paused() bool {
+	return l.paused
+}
+
+// Stop stops the Loop for good and releases its JavaScript callbacks. Stop
+// can be called from within the render function.
+func (l *Loop) Stop() {
+	if l.stopped {
+		return
+	}
+	l.stopped = true
+	if l.running {
+		l.cancelFrame()
+		l.frameCallback.Release()
+		if l.visibilityCallback.Truthy() {
+			js.Global().Get("document").Call("removeEventListener", "visibilitychange", l.visibilityCallback)
+			l.visibilityCallback.Release()
+		}
+	}
+	l.running = false
+	l.doneOnce.Do(func() {
+		close(l.done)
+	})
+}
+
+// Done returns a channel that is closed once the Loop has been stopped.
+func (l *Loop) Done() <-chan struct{} {
+	return l.done
+}
+
+// Stats returns timing statistics about the recent frames.
+func (l *Loop) Stats() LoopStats {
+	average, worst := l.frameTimes.summary()
+	return LoopStats{
+		Frames:           l.index,
+		AverageFrameTime: average,
+		WorstFrameTime:   worst,
+	}
+}
+
+func (l *Loop) requestFrame() {
+	l.requestID = js.Global().Call("requestAnimationFrame", l.frameCallback)
+}
+
+func (l *Loop) cancelFrame() {
+	if l.requestID.Truthy() {
+		js.Global().Call("cancelAnimationFrame", l.requestID)
+		l.requestID = js.Undefined()
+	}
+}
+
+func (l *Loop) onFrame(stamp float64) {
+	l.requestID = js.Undefined()
+	if l.stopped || l.paused {
+		return
+	}
+
+	var delta time.Duration
+	if l.hasStamp {
+		delta = time.Duration((stamp - l.lastStamp) * float64(time.Millisecond))
+		l.frameTimes.add(delta)
+	}
+	l.lastStamp = stamp
+	l.hasStamp = true
+	l.time += delta
+
+	if l.surface != nil {
+		l.surface.Update()
+	}
+
+	var interpolation float64
+	if l.update != nil {
+		var steps int
+		steps, interpolation = l.fixed.advance(delta)
+		for range steps {
+			l.update(l.fixed.step)
+		}
+	}
+
+	l.render(Frame{
+		Index:         l.index,
+		Time:          l.time,
+		Delta:         delta,
+		Interpolation: interpolation,
+	})
+	l.index++
+
+	// NOTE: The render function may have already requested the next frame
+	// by calling Pause and Resume.
+	if !l.stopped && !l.paused && !l.requestID.Truthy() {
+		l.requestFrame()
+	}
+}
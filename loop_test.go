@@ -0,0 +1,133 @@
+package wasmgl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedSteps(t *testing.T) {
+	const ms = time.Millisecond
+	testCases := []struct {
+		name              string
+		step              time.Duration
+		deltas            []time.Duration
+		wantSteps         int
+		wantInterpolation float64
+	}{
+		{"no step", 0, []time.Duration{100 * ms}, 0, 0},
+		{"less than a step", 10 * ms, []time.Duration{4 * ms}, 0, 0.4},
+		{"exact step", 10 * ms, []time.Duration{10 * ms}, 1, 0},
+		{"accumulated steps", 10 * ms, []time.Duration{6 * ms, 6 * ms}, 1, 0.2},
+		{"several steps", 10 * ms, []time.Duration{35 * ms}, 3, 0.5},
+		{"maximum steps", 10 * ms, []time.Duration{85 * ms}, 8, 0.5},
+		{"backlog dropped", 10 * ms, []time.Duration{1000 * ms}, 8, 0},
+		{"backlog dropped with fraction", 10 * ms, []time.Duration{1005 * ms}, 8, 0.5},
+		{"no steps after dropped backlog", 10 * ms, []time.Duration{1005 * ms, 2 * ms}, 0, 0.7},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := fixedSteps{step: tc.step}
+			var steps int
+			var interpolation float64
+			for _, delta := range tc.deltas {
+				steps, interpolation = s.advance(delta)
+			}
+			if steps != tc.wantSteps {
+				t.Errorf("steps = %d, want %d", steps, tc.wantSteps)
+			}
+			if diff := interpolation - tc.wantInterpolation; diff < -1e-9 || diff > 1e-9 {
+				t.Errorf("interpolation = %v, want %v", interpolation, tc.wantInterpolation)
+			}
+		})
+	}
+}
+
+func TestFixedStepsInterpolationRange(t *testing.T) {
+	testCases := []struct {
+		name  string
+		step  time.Duration
+		delta time.Duration
+	}{
+		{"frame shorter than step", 16 * time.Millisecond, 7 * time.Millisecond},
+		{"frame longer than step", 10 * time.Millisecond, 16*time.Millisecond + 700*time.Microsecond},
+		{"frame far longer than step", time.Millisecond, 33 * time.Millisecond},
+		{"uneven step", 7 * time.Millisecond, 16 * time.Millisecond},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := fixedSteps{step: tc.step}
+			for range 1000 {
+				steps, interpolation := s.advance(tc.delta)
+				if steps < 0 || steps > maxUpdateSteps {
+					t.Fatalf("steps = %d, want in range [0, %d]", steps, maxUpdateSteps)
+				}
+				if interpolation < 0 || interpolation >= 1 {
+					t.Fatalf("interpolation = %v, want in range [0, 1)", interpolation)
+				}
+			}
+		})
+	}
+}
+
+func TestFrameTimes(t *testing.T) {
+	const ms = time.Millisecond
+	testCases := []struct {
+		name        string
+		frames      []time.Duration
+		wantAverage time.Duration
+		wantWorst   time.Duration
+	}{
+		{
+			name:        "no frames",
+			wantAverage: 0,
+			wantWorst:   0,
+		},
+		{
+			name:        "partial window",
+			frames:      []time.Duration{10 * ms, 20 * ms, 30 * ms},
+			wantAverage: 20 * ms,
+			wantWorst:   30 * ms,
+		},
+		{
+			name:        "full window",
+			frames:      append(repeatFrameTime(10*ms, statsWindow-1), 130*ms),
+			wantAverage: 11 * ms,
+			wantWorst:   130 * ms,
+		},
+		{
+			name:        "wrapped window",
+			frames:      append(repeatFrameTime(100*ms, 10), repeatFrameTime(10*ms, statsWindow)...),
+			wantAverage: 10 * ms,
+			wantWorst:   10 * ms,
+		},
+		{
+			name:        "partially wrapped window",
+			frames:      append(repeatFrameTime(130*ms, 2), repeatFrameTime(10*ms, statsWindow-1)...),
+			wantAverage: 11 * ms,
+			wantWorst:   130 * ms,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var f frameTimes
+			for _, frameTime := range tc.frames {
+				f.add(frameTime)
+			}
+			average, worst := f.summary()
+			if average != tc.wantAverage {
+				t.Errorf("average = %v, want %v", average, tc.wantAverage)
+			}
+			if worst != tc.wantWorst {
+				t.Errorf("worst = %v, want %v", worst, tc.wantWorst)
+			}
+		})
+	}
+}
+
+func repeatFrameTime(frameTime time.Duration, count int) []time.Duration {
+	result := make([]time.Duration, count)
+	for i := range result {
+		result[i] = frameTime
+	}
+	return result
+}
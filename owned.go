@@ -0,0 +1,148 @@
+package wasmgl
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// NewDeletionQueue returns a new empty DeletionQueue.
+func NewDeletionQueue() *DeletionQueue {
+	return &DeletionQueue{}
+}
+
+// DeletionQueue collects the deletion of WebGL objects that were owned by
+// Owned handles which became unreachable without being closed.
+//
+// Finalizers run on a separate goroutine, where calling WebGL functions is
+// not safe. Instead, the deletions are queued and performed once Drain is
+// called, which should happen regularly (e.g. once per frame) on the
+// goroutine that does the rendering.
+type DeletionQueue struct {
+	mu      sync.Mutex
+	pending []func()
+}
+
+// Len returns the number of deletions that are waiting for Drain.
+func (q *DeletionQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.pending)
+}
+
+// Drain performs all queued deletions and returns their count.
+//
+// This function must be called on the rendering goroutine.
+func (q *DeletionQueue) Drain() int {
+	q.mu.Lock()
+	pending := q.pending
+	q.pending = nil
+	q.mu.Unlock()
+
+	for _, del := range pending {
+		del()
+	}
+	return len(pending)
+}
+
+func (q *DeletionQueue) enqueue(del func()) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pending = append(q.pending, del)
+}
+
+// Owned holds a WebGL object handle and is responsible for deleting the
+// underlying object. The object is deleted when Close is called or, if that
+// is forgotten, through the DeletionQueue once the Owned becomes
+// unreachable.
+//
+// Relying on the garbage collector is a safety net and not a replacement
+// for Close, since there are no guarantees as to when (or whether) the
+// collection happens.
+//
+// The Own functions panic if the specified DeletionQueue is nil.
+type Owned[T any] struct {
+	handle T
+	delete func(T)
+}
+
+// Handle returns the object handle that is owned. The handle must not be
+// used after Close has been called.
+func (o *Owned[T]) Handle() T {
+	return o.handle
+}
+
+// Close deletes the owned object. Calling Close more than once has no
+// effect.
+//
+// This function must be called on the rendering goroutine.
+func (o *Owned[T]) Close() {
+	if o.delete == nil {
+		return
+	}
+	runtime.SetFinalizer(o, nil)
+	o.delete(o.handle)
+	o.delete = nil
+}
+
+// OwnBuffer returns an Owned that deletes the specified Buffer.
+func OwnBuffer(queue *DeletionQueue, buffer Buffer) *Owned[Buffer] {
+	return own(queue, buffer, DeleteBuffer)
+}
+
+// OwnFramebuffer returns an Owned that deletes the specified Framebuffer.
+func OwnFramebuffer(queue *DeletionQueue, framebuffer Framebuffer) *Owned[Framebuffer] {
+	return own(queue, framebuffer, DeleteFramebuffer)
+}
+
+// OwnProgram returns an Owned that deletes the specified Program.
+func OwnProgram(queue *DeletionQueue, program Program) *Owned[Program] {
+	return own(queue, program, DeleteProgram)
+}
+
+// OwnRenderbuffer returns an Owned that deletes the specified Renderbuffer.
+func OwnRenderbuffer(queue *DeletionQueue, renderbuffer Renderbuffer) *Owned[Renderbuffer] {
+	return own(queue, renderbuffer, DeleteRenderbuffer)
+}
+
+// OwnSampler returns an Owned that deletes the specified Sampler.
+func OwnSampler(queue *DeletionQueue, sampler Sampler) *Owned[Sampler] {
+	return own(queue, sampler, DeleteSampler)
+}
+
+// OwnShader returns an Owned that deletes the specified Shader.
+func OwnShader(queue *DeletionQueue, shader Shader) *Owned[Shader] {
+	return own(queue, shader, DeleteShader)
+}
+
+// OwnSync returns an Owned that deletes the specified Sync.
+func OwnSync(queue *DeletionQueue, sync Sync) *Owned[Sync] {
+	return own(queue, sync, DeleteSync)
+}
+
+// OwnTexture returns an Owned that deletes the specified Texture.
+func OwnTexture(queue *DeletionQueue, texture Texture) *Owned[Texture] {
+	return own(queue, texture, DeleteTexture)
+}
+
+// OwnVertexArray returns an Owned that deletes the specified VertexArray.
+func OwnVertexArray(queue *DeletionQueue, array VertexArray) *Owned[VertexArray] {
+	return own(queue, array, DeleteVertexArray)
+}
+
+func own[T any](queue *DeletionQueue, handle T, del func(T)) *Owned[T] {
+	if queue == nil {
+		panic(fmt.Errorf("deletion queue must not be nil"))
+	}
+	result := &Owned[T]{
+		handle: handle,
+		delete: del,
+	}
+	runtime.SetFinalizer(result, func(o *Owned[T]) {
+		handle, del := o.handle, o.delete
+		queue.enqueue(func() {
+			del(handle)
+		})
+	})
+	return result
+}
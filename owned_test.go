@@ -0,0 +1,105 @@
+package wasmgl_test
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/mokiat/wasmgl"
+	"github.com/mokiat/wasmgl/wasmgltest"
+)
+
+func TestOwnedClose(t *testing.T) {
+	testCases := []struct {
+		name     string
+		own      func(queue *wasmgl.DeletionQueue) (close func())
+		function string
+	}{
+		{
+			name: "buffer",
+			own: func(queue *wasmgl.DeletionQueue) func() {
+				return wasmgl.OwnBuffer(queue, wasmgl.CreateBuffer()).Close
+			},
+			function: "DeleteBuffer",
+		},
+		{
+			name: "texture",
+			own: func(queue *wasmgl.DeletionQueue) func() {
+				return wasmgl.OwnTexture(queue, wasmgl.CreateTexture()).Close
+			},
+			function: "DeleteTexture",
+		},
+		{
+			name: "program",
+			own: func(queue *wasmgl.DeletionQueue) func() {
+				return wasmgl.OwnProgram(queue, wasmgl.CreateProgram()).Close
+			},
+			function: "DeleteProgram",
+		},
+		{
+			name: "vertex array",
+			own: func(queue *wasmgl.DeletionQueue) func() {
+				return wasmgl.OwnVertexArray(queue, wasmgl.CreateVertexArray()).Close
+			},
+			function: "DeleteVertexArray",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			useBackend(t, b)
+
+			queue := wasmgl.NewDeletionQueue()
+			closeFn := tc.own(queue)
+			closeFn()
+			closeFn()
+
+			if got := len(b.CallsTo(tc.function)); got != 1 {
+				t.Errorf("got %d calls to %s, want 1", got, tc.function)
+			}
+			if live := b.LiveObjects(); live != 0 {
+				t.Errorf("got %d live objects, want 0", live)
+			}
+			if pending := queue.Len(); pending != 0 {
+				t.Errorf("got %d queued deletions, want 0", pending)
+			}
+		})
+	}
+}
+
+func TestOwnedUnreachable(t *testing.T) {
+	b := wasmgltest.NewBackend()
+	useBackend(t, b)
+
+	queue := wasmgl.NewDeletionQueue()
+	func() {
+		wasmgl.OwnBuffer(queue, wasmgl.CreateBuffer())
+	}()
+	for i := 0; i < 50 && queue.Len() == 0; i++ {
+		runtime.GC()
+		time.Sleep(time.Millisecond)
+	}
+	if pending := queue.Len(); pending != 1 {
+		t.Fatalf("got %d queued deletions, want 1", pending)
+	}
+	if live := b.LiveObjects(); live != 1 {
+		t.Fatalf("got %d live objects before Drain, want 1", live)
+	}
+	if drained := queue.Drain(); drained != 1 {
+		t.Errorf("Drain() = %d, want 1", drained)
+	}
+	if live := b.LiveObjects(); live != 0 {
+		t.Errorf("got %d live objects after Drain, want 0", live)
+	}
+}
+
+func TestOwnNilQueue(t *testing.T) {
+	useBackend(t, wasmgltest.NewBackend())
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected panic for nil deletion queue")
+		}
+	}()
+	wasmgl.OwnBuffer(nil, wasmgl.CreateBuffer())
+}
@@ -0,0 +1,199 @@
+//go:build js && wasm
+
+package wasmgl
+
+import (
+	"errors"
+	"fmt"
+	"syscall/js"
+)
+
+// defaultRecorderMIMEType is the MIME type that is used for recordings if
+// none is configured.
+const defaultRecorderMIMEType = "video/webm"
+
+// RecorderOption represents a configuration option for a Recorder.
+type RecorderOption func(r *Recorder)
+
+// WithRecorderFrameRate configures the maximum frame rate at which the
+// canvas is captured. This option is ignored in frame-accurate mode.
+func WithRecorderFrameRate(frameRate float64) RecorderOption {
+	return func(r *Recorder) {
+		r.frameRate = frameRate
+	}
+}
+
+// WithRecorderMIMEType configures the MIME type of the recording (e.g.
+// "video/webm;codecs=vp9"). The default is "video/webm".
+func WithRecorderMIMEType(mimeType string) RecorderOption {
+	return func(r *Recorder) {
+		r.mimeType = mimeType
+	}
+}
+
+// WithRecorderBitrate configures the video bitrate of the recording in bits
+// per second. By default, the browser picks the bitrate.
+func WithRecorderBitrate(bitsPerSecond int) RecorderOption {
+	return func(r *Recorder) {
+		r.bitrate = bitsPerSecond
+	}
+}
+
+// WithRecorderFrameAccurate enables the frame-accurate mode, where a frame
+// is captured only when RequestFrame is called, which should happen after
+// each rendered frame. This way the recording contains every frame exactly
+// once, regardless of timing.
+func WithRecorderFrameAccurate(frameAccurate bool) RecorderOption {
+	return func(r *Recorder) {
+		r.frameAccurate = frameAccurate
+	}
+}
+
+// StartRecording starts recording the canvas of the current context to a
+// video through captureStream and MediaRecorder. The recording needs to be
+// finished through Stop.
+func StartRecording(opts ...RecorderOption) (*Recorder, error) {
+	r := &Recorder{
+		mimeType: defaultRecorderMIMEType,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	mediaRecorder := js.Global().Get("MediaRecorder")
+	if mediaRecorder.Type() != js.TypeFunction {
+		return nil, errors.New("MediaRecorder is not supported")
+	}
+	if !mediaRecorder.Call("isTypeSupported", r.mimeType).Bool() {
+		return nil, fmt.Errorf("MIME type %q is not supported", r.mimeType)
+	}
+	canvas := context.Get("canvas")
+	if canvas.Get("captureStream").Type() != js.TypeFunction {
+		return nil, errors.New("canvas does not support captureStream")
+	}
+
+	if r.frameAccurate {
+		r.stream = canvas.Call("captureStream", 0)
+	} else if r.frameRate > 0 {
+		r.stream = canvas.Call("captureStream", r.frameRate)
+	} else {
+		r.stream = canvas.Call("captureStream")
+	}
+	r.track = r.stream.Call("getVideoTracks").Index(0)
+
+	options := js.Global().Get("Object").New()
+	options.Set("mimeType", r.mimeType)
+	if r.bitrate > 0 {
+		options.Set("videoBitsPerSecond", r.bitrate)
+	}
+	r.recorder = mediaRecorder.New(r.stream, options)
+	r.chunks = js.Global().Get("Array").New()
+
+	r.onData = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if data := firstArg(args).Get("data"); data.Type() == js.TypeObject && data.Get("size").Int() > 0 {
+			r.chunks.Call("push", data)
+		}
+		return nil
+	})
+	r.onError = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if r.err == nil {
+			r.err = js.Error{Value: firstArg(args).Get("error")}
+		}
+		return nil
+	})
+	r.onStop = js.FuncOf(func(this js.Value, args []js.Value) any {
+		r.finish()
+		return nil
+	})
+	r.recorder.Call("addEventListener", "dataavailable", r.onData)
+	r.recorder.Call("addEventListener", "error", r.onError)
+	r.recorder.Call("addEventListener", "stop", r.onStop)
+	r.recorder.Call("start")
+	return r, nil
+}
+
+// Recorder records the canvas of the context to a video.
+type Recorder struct {
+	frameRate     float64
+	mimeType      string
+	bitrate       int
+	frameAccurate bool
+
+	stream   js.Value
+	track    js.Value
+	recorder js.Value
+	chunks   js.Value
+
+	onData  js.Func
+	onError js.Func
+	onStop  js.Func
+
+	stopped  bool
+	finished bool
+	err      error
+	done     func(data []byte, err error)
+}
+
+// MIMEType returns the MIME type of the recording.
+func (r *Recorder) MIMEType() string {
+	return r.mimeType
+}
+
+// RequestFrame captures the current contents of the canvas as a frame in
+// frame-accurate mode and has no effect otherwise. It needs to be called
+// after rendering and before returning from the frame.
+func (r *Recorder) RequestFrame() {
+	if !r.frameAccurate || r.stopped {
+		return
+	}
+	r.track.Call("requestFrame")
+}
+
+// Stop finishes the recording. Once the browser has produced the remaining
+// data, the complete video file is passed to the specified function, which
+// must not block. Calling Stop more than once has no effect.
+//
+// Stop needs to be called even if the browser has ended the recording on
+// its own (e.g. due to an error), in order to obtain the result.
+func (r *Recorder) Stop(done func(data []byte, err error)) {
+	if r.stopped {
+		return
+	}
+	r.stopped = true
+	r.done = done
+	if r.finished {
+		r.deliver()
+		return
+	}
+	r.recorder.Call("stop")
+}
+
+func (r *Recorder) finish() {
+	r.recorder.Call("removeEventListener", "dataavailable", r.onData)
+	r.recorder.Call("removeEventListener", "error", r.onError)
+	r.recorder.Call("removeEventListener", "stop", r.onStop)
+	r.onData.Release()
+	r.onError.Release()
+	r.onStop.Release()
+
+	tracks := r.stream.Call("getTracks")
+	for i := range tracks.Length() {
+		tracks.Index(i).Call("stop")
+	}
+
+	r.finished = true
+	if r.done != nil {
+		r.deliver()
+	}
+}
+
+func (r *Recorder) deliver() {
+	if r.err != nil {
+		r.done(nil, r.err)
+		return
+	}
+	options := js.Global().Get("Object").New()
+	options.Set("type", r.mimeType)
+	blob := js.Global().Get("Blob").New(r.chunks, options)
+	blobBytes(blob, r.done)
+}
@@ -0,0 +1,58 @@
+package wasmgl
+
+import "image"
+
+// ReadImage reads the specified rectangle of the color buffer of the
+// specified framebuffer (NilFramebuffer for the default framebuffer) into
+// a new image. The rows are flipped, so that the top row of the rectangle
+// is the first row of the image.
+//
+// If premultiplied is true, then the colors are divided by alpha, which is
+// needed for the default framebuffer of a context that has alpha and
+// premultipliedAlpha enabled.
+//
+// The framebuffer is left bound to READ_FRAMEBUFFER. No buffer may be bound
+// to PIXEL_PACK_BUFFER.
+func ReadImage(framebuffer Framebuffer, x, y, width, height int, premultiplied bool) (*image.NRGBA, error) {
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	if err := checkCall("ReadPixels", func() {
+		BindFramebuffer(READ_FRAMEBUFFER, framebuffer)
+		ReadPixelsData(GLint(x), GLint(y), GLsizei(width), GLsizei(height), RGBA, UNSIGNED_BYTE, img.Pix)
+	}); err != nil {
+		return nil, err
+	}
+	flipRows(img)
+	if premultiplied {
+		unpremultiply(img.Pix)
+	}
+	return img, nil
+}
+
+// flipRows reverses the order of the rows of the specified image, since
+// WebGL returns pixels starting with the bottom row.
+func flipRows(img *image.NRGBA) {
+	height := img.Rect.Dy()
+	rowSize := 4 * img.Rect.Dx()
+	row := make([]byte, rowSize)
+	for top, bottom := 0, height-1; top < bottom; top, bottom = top+1, bottom-1 {
+		topRow := img.Pix[top*img.Stride : top*img.Stride+rowSize]
+		bottomRow := img.Pix[bottom*img.Stride : bottom*img.Stride+rowSize]
+		copy(row, topRow)
+		copy(topRow, bottomRow)
+		copy(bottomRow, row)
+	}
+}
+
+// unpremultiply converts the specified RGBA pixels with premultiplied alpha
+// to straight alpha.
+func unpremultiply(pix []byte) {
+	for i := 0; i+3 < len(pix); i += 4 {
+		alpha := int(pix[i+3])
+		if alpha == 0 || alpha == 255 {
+			continue
+		}
+		for c := i; c < i+3; c++ {
+			pix[c] = byte(min((int(pix[c])*255+alpha/2)/alpha, 255))
+		}
+	}
+}
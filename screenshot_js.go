@@ -0,0 +1,63 @@
+//go:build js && wasm
+
+package wasmgl
+
+import (
+	"errors"
+	"image"
+	"syscall/js"
+)
+
+// Screenshot reads the contents of the default framebuffer (i.e. what is
+// shown on the canvas) into a new image, taking premultipliedAlpha into
+// account.
+//
+// Unless the context has been created with preserveDrawingBuffer, the
+// drawing buffer is cleared once the frame has been presented, so this
+// function needs to be called after rendering and before returning from the
+// frame.
+func Screenshot() (*image.NRGBA, error) {
+	attributes := GetContextAttributes()
+	premultiplied := attributes.Alpha && attributes.PremultipliedAlpha
+	return ReadImage(NilFramebuffer, 0, 0, DrawingBufferWidth(), DrawingBufferHeight(), premultiplied)
+}
+
+// CapturePNG encodes the contents of the canvas as PNG through the browser,
+// which is faster than encoding the result of Screenshot in Go. The
+// contents are captured when CapturePNG is called, while the encoding
+// happens asynchronously and the result is passed to the specified
+// function once it is available. The function must not block.
+//
+// Like Screenshot, this function needs to be called after rendering and
+// before returning from the frame.
+func CapturePNG(done func(data []byte, err error)) {
+	canvas := context.Get("canvas")
+	onBlob := func(blob js.Value) {
+		if blob.Type() != js.TypeObject {
+			done(nil, errors.New("canvas could not be encoded as png"))
+			return
+		}
+		blobBytes(blob, done)
+	}
+
+	// NOTE: OffscreenCanvas does not have toBlob but has convertToBlob.
+	if canvas.Get("toBlob").Type() == js.TypeFunction {
+		var callback js.Func
+		callback = js.FuncOf(func(this js.Value, args []js.Value) any {
+			callback.Release()
+			onBlob(firstArg(args))
+			return nil
+		})
+		canvas.Call("toBlob", callback, "image/png")
+		return
+	}
+	options := js.Global().Get("Object").New()
+	options.Set("type", "image/png")
+	onPromise(canvas.Call("convertToBlob", options), func(blob js.Value, err error) {
+		if err != nil {
+			done(nil, err)
+			return
+		}
+		onBlob(blob)
+	})
+}
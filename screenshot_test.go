@@ -0,0 +1,89 @@
+package wasmgl
+
+import (
+	"bytes"
+	"image"
+	"testing"
+)
+
+func TestFlipRows(t *testing.T) {
+	testCases := []struct {
+		name  string
+		width int
+		pix   []byte
+		want  []byte
+	}{
+		{
+			name:  "single row",
+			width: 2,
+			pix:   []byte{1, 1, 1, 1, 2, 2, 2, 2},
+			want:  []byte{1, 1, 1, 1, 2, 2, 2, 2},
+		},
+		{
+			name:  "even rows",
+			width: 1,
+			pix:   []byte{1, 1, 1, 1, 2, 2, 2, 2},
+			want:  []byte{2, 2, 2, 2, 1, 1, 1, 1},
+		},
+		{
+			name:  "odd rows",
+			width: 1,
+			pix:   []byte{1, 1, 1, 1, 2, 2, 2, 2, 3, 3, 3, 3},
+			want:  []byte{3, 3, 3, 3, 2, 2, 2, 2, 1, 1, 1, 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			height := len(tc.pix) / (4 * tc.width)
+			img := image.NewNRGBA(image.Rect(0, 0, tc.width, height))
+			copy(img.Pix, tc.pix)
+			flipRows(img)
+			if !bytes.Equal(img.Pix, tc.want) {
+				t.Errorf("got %v, want %v", img.Pix, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnpremultiply(t *testing.T) {
+	testCases := []struct {
+		name string
+		pix  []byte
+		want []byte
+	}{
+		{
+			name: "opaque",
+			pix:  []byte{10, 20, 30, 255},
+			want: []byte{10, 20, 30, 255},
+		},
+		{
+			name: "transparent",
+			pix:  []byte{0, 0, 0, 0},
+			want: []byte{0, 0, 0, 0},
+		},
+		{
+			name: "half transparent",
+			pix:  []byte{64, 32, 0, 128},
+			want: []byte{128, 64, 0, 128},
+		},
+		{
+			name: "clamped",
+			pix:  []byte{200, 0, 0, 100},
+			want: []byte{255, 0, 0, 100},
+		},
+		{
+			name: "multiple pixels",
+			pix:  []byte{10, 20, 30, 255, 64, 32, 0, 128},
+			want: []byte{10, 20, 30, 255, 128, 64, 0, 128},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pix := bytes.Clone(tc.pix)
+			unpremultiply(pix)
+			if !bytes.Equal(pix, tc.want) {
+				t.Errorf("got %v, want %v", pix, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,507 @@
+package wasmgl
+
+// NewStateCache returns a StateCache that forwards calls to the specified
+// delegate Backend.
+func NewStateCache(delegate Backend) *StateCache {
+	c := &StateCache{
+		Backend: delegate,
+	}
+	c.Invalidate()
+	return c
+}
+
+var _ Backend = (*StateCache)(nil)
+
+// StateCache is a Backend that keeps a shadow copy of the WebGL state
+// and skips calls that would not change anything (e.g. binding a texture
+// that is already bound). All other calls are forwarded to the delegate
+// Backend unchanged.
+//
+// The shadow state starts out unknown, so the first call for each piece of
+// state is always forwarded. If code outside of this package (e.g.
+// JavaScript libraries) changes the WebGL state, then Invalidate needs to
+// be called afterwards.
+type StateCache struct {
+	// Backend is the delegate Backend that calls are forwarded to.
+	Backend
+
+	stats StateCacheStats
+
+	activeTexture cachedValue[GLenum]
+	buffers       map[GLenum]Buffer
+	textures      map[textureUnitTarget]Texture
+	samplers      map[GLuint]Sampler
+	program       cachedValue[Program]
+	vertexArray   cachedValue[VertexArray]
+	drawFramebuf  cachedValue[Framebuffer]
+	readFramebuf  cachedValue[Framebuffer]
+	capabilities  map[GLenum]bool
+
+	blendColor    cachedValue[[4]GLclampf]
+	blendEquation cachedValue[[2]GLenum]
+	blendFunc     cachedValue[[4]GLenum]
+	colorMask     cachedValue[[4]GLboolean]
+	cullFace      cachedValue[GLenum]
+	frontFace     cachedValue[GLenum]
+	depthFunc     cachedValue[GLenum]
+	depthMask     cachedValue[GLboolean]
+	stencilFunc   [2]cachedValue[stencilFuncState]
+	stencilMask   [2]cachedValue[GLuint]
+	stencilOp     [2]cachedValue[[3]GLenum]
+	viewport      cachedValue[[4]GLint]
+	scissor       cachedValue[[4]GLint]
+}
+
+// StateCacheStats holds statistics about the calls that passed through
+// a StateCache.
+type StateCacheStats struct {
+
+	// Forwarded is the number of state-changing calls that were forwarded
+	// to the delegate Backend.
+	Forwarded uint64
+
+	// Filtered is the number of state-changing calls that were skipped
+	// because they would not have changed anything.
+	Filtered uint64
+}
+
+// Stats returns statistics about the calls that have passed through this
+// StateCache since it was created or since ResetStats was last called.
+func (c *StateCache) Stats() StateCacheStats {
+	return c.stats
+}
+
+// ResetStats resets the statistics of this StateCache.
+func (c *StateCache) ResetStats() {
+	c.stats = StateCacheStats{}
+}
+
+// Invalidate marks the whole shadow state as unknown, which causes
+// subsequent calls to be forwarded to the delegate Backend. This needs to
+// be called whenever the WebGL state is changed by code that does not go
+// through this StateCache.
+func (c *StateCache) Invalidate() {
+	c.activeTexture.reset()
+	c.buffers = make(map[GLenum]Buffer)
+	c.textures = make(map[textureUnitTarget]Texture)
+	c.samplers = make(map[GLuint]Sampler)
+	c.program.reset()
+	c.vertexArray.reset()
+	c.drawFramebuf.reset()
+	c.readFramebuf.reset()
+	c.capabilities = make(map[GLenum]bool)
+
+	c.blendColor.reset()
+	c.blendEquation.reset()
+	c.blendFunc.reset()
+	c.colorMask.reset()
+	c.cullFace.reset()
+	c.frontFace.reset()
+	c.depthFunc.reset()
+	c.depthMask.reset()
+	for i := range c.stencilFunc {
+		c.stencilFunc[i].reset()
+		c.stencilMask[i].reset()
+		c.stencilOp[i].reset()
+	}
+	c.viewport.reset()
+	c.scissor.reset()
+}
+
+// filter updates the statistics and returns whether the call should be
+// skipped.
+func (c *StateCache) filter(unchanged bool) bool {
+	if unchanged {
+		c.stats.Filtered++
+	} else {
+		c.stats.Forwarded++
+	}
+	return unchanged
+}
+
+func (c *StateCache) ActiveTexture(texture GLenum) {
+	if c.filter(c.activeTexture.is(texture)) {
+		return
+	}
+	c.Backend.ActiveTexture(texture)
+	c.activeTexture.set(texture)
+}
+
+func (c *StateCache) BindBuffer(target GLenum, buffer Buffer) {
+	if c.filter(bindingIs(c.buffers, target, buffer)) {
+		return
+	}
+	c.Backend.BindBuffer(target, buffer)
+	c.buffers[target] = buffer
+}
+
+func (c *StateCache) BindBufferBase(target GLenum, index GLuint, buffer Buffer) {
+	c.Backend.BindBufferBase(target, index, buffer)
+	// NOTE: This also binds the buffer to the generic binding point.
+	c.buffers[target] = buffer
+}
+
+func (c *StateCache) BindBufferRange(target GLenum, index GLuint, buffer Buffer, offset GLintptr, size GLsizeiptr) {
+	c.Backend.BindBufferRange(target, index, buffer, offset, size)
+	// NOTE: This also binds the buffer to the generic binding point.
+	c.buffers[target] = buffer
+}
+
+func (c *StateCache) BindFramebuffer(target GLenum, framebuffer Framebuffer) {
+	var unchanged bool
+	switch target {
+	case FRAMEBUFFER:
+		unchanged = c.drawFramebuf.is(framebuffer) && c.readFramebuf.is(framebuffer)
+	case DRAW_FRAMEBUFFER:
+		unchanged = c.drawFramebuf.is(framebuffer)
+	case READ_FRAMEBUFFER:
+		unchanged = c.readFramebuf.is(framebuffer)
+	}
+	if c.filter(unchanged) {
+		return
+	}
+	c.Backend.BindFramebuffer(target, framebuffer)
+	switch target {
+	case FRAMEBUFFER:
+		c.drawFramebuf.set(framebuffer)
+		c.readFramebuf.set(framebuffer)
+	case DRAW_FRAMEBUFFER:
+		c.drawFramebuf.set(framebuffer)
+	case READ_FRAMEBUFFER:
+		c.readFramebuf.set(framebuffer)
+	}
+}
+
+func (c *StateCache) BindSampler(unit GLuint, sampler Sampler) {
+	if c.filter(bindingIs(c.samplers, unit, sampler)) {
+		return
+	}
+	c.Backend.BindSampler(unit, sampler)
+	c.samplers[unit] = sampler
+}
+
+func (c *StateCache) BindTexture(target GLenum, texture Texture) {
+	if !c.activeTexture.valid {
+		// NOTE: Without knowing the active unit, the binding cannot be
+		// tracked.
+		c.filter(false)
+		c.Backend.BindTexture(target, texture)
+		return
+	}
+	key := textureUnitTarget{unit: c.activeTexture.value, target: target}
+	if c.filter(bindingIs(c.textures, key, texture)) {
+		return
+	}
+	c.Backend.BindTexture(target, texture)
+	c.textures[key] = texture
+}
+
+func (c *StateCache) BindVertexArray(array VertexArray) {
+	if c.filter(c.vertexArray.is(array)) {
+		return
+	}
+	c.Backend.BindVertexArray(array)
+	c.vertexArray.set(array)
+	// NOTE: The element array buffer binding is part of the vertex array
+	// state.
+	delete(c.buffers, ELEMENT_ARRAY_BUFFER)
+}
+
+func (c *StateCache) BlendColor(red, green, blue, alpha GLclampf) {
+	value := [4]GLclampf{red, green, blue, alpha}
+	if c.filter(c.blendColor.is(value)) {
+		return
+	}
+	c.Backend.BlendColor(red, green, blue, alpha)
+	c.blendColor.set(value)
+}
+
+func (c *StateCache) BlendEquation(mode GLenum) {
+	value := [2]GLenum{mode, mode}
+	if c.filter(c.blendEquation.is(value)) {
+		return
+	}
+	c.Backend.BlendEquation(mode)
+	c.blendEquation.set(value)
+}
+
+func (c *StateCache) BlendEquationSeparate(modeRGB, modeAlpha GLenum) {
+	value := [2]GLenum{modeRGB, modeAlpha}
+	if c.filter(c.blendEquation.is(value)) {
+		return
+	}
+	c.Backend.BlendEquationSeparate(modeRGB, modeAlpha)
+	c.blendEquation.set(value)
+}
+
+func (c *StateCache) BlendFunc(sfactor, dfactor GLenum) {
+	value := [4]GLenum{sfactor, dfactor, sfactor, dfactor}
+	if c.filter(c.blendFunc.is(value)) {
+		return
+	}
+	c.Backend.BlendFunc(sfactor, dfactor)
+	c.blendFunc.set(value)
+}
+
+func (c *StateCache) BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha GLenum) {
+	value := [4]GLenum{srcRGB, dstRGB, srcAlpha, dstAlpha}
+	if c.filter(c.blendFunc.is(value)) {
+		return
+	}
+	c.Backend.BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha)
+	c.blendFunc.set(value)
+}
+
+func (c *StateCache) ColorMask(r, g, b, a GLboolean) {
+	value := [4]GLboolean{r, g, b, a}
+	if c.filter(c.colorMask.is(value)) {
+		return
+	}
+	c.Backend.ColorMask(r, g, b, a)
+	c.colorMask.set(value)
+}
+
+func (c *StateCache) CullFace(mode GLenum) {
+	if c.filter(c.cullFace.is(mode)) {
+		return
+	}
+	c.Backend.CullFace(mode)
+	c.cullFace.set(mode)
+}
+
+func (c *StateCache) DeleteBuffer(buffer Buffer) {
+	c.Backend.DeleteBuffer(buffer)
+	forgetBinding(c.buffers, buffer)
+}
+
+func (c *StateCache) DeleteFramebuffer(framebuffer Framebuffer) {
+	c.Backend.DeleteFramebuffer(framebuffer)
+	if c.drawFramebuf.is(framebuffer) {
+		c.drawFramebuf.set(NilFramebuffer)
+	}
+	if c.readFramebuf.is(framebuffer) {
+		c.readFramebuf.set(NilFramebuffer)
+	}
+}
+
+func (c *StateCache) DeleteSampler(sampler Sampler) {
+	c.Backend.DeleteSampler(sampler)
+	forgetBinding(c.samplers, sampler)
+}
+
+func (c *StateCache) DeleteTexture(texture Texture) {
+	c.Backend.DeleteTexture(texture)
+	forgetBinding(c.textures, texture)
+}
+
+func (c *StateCache) DeleteVertexArray(array VertexArray) {
+	c.Backend.DeleteVertexArray(array)
+	if c.vertexArray.is(array) {
+		c.vertexArray.set(NilVertexArray)
+		delete(c.buffers, ELEMENT_ARRAY_BUFFER)
+	}
+}
+
+func (c *StateCache) DepthFunc(fn GLenum) {
+	if c.filter(c.depthFunc.is(fn)) {
+		return
+	}
+	c.Backend.DepthFunc(fn)
+	c.depthFunc.set(fn)
+}
+
+func (c *StateCache) DepthMask(mask GLboolean) {
+	if c.filter(c.depthMask.is(mask)) {
+		return
+	}
+	c.Backend.DepthMask(mask)
+	c.depthMask.set(mask)
+}
+
+func (c *StateCache) Disable(cap GLenum) {
+	if c.filter(bindingIs(c.capabilities, cap, false)) {
+		return
+	}
+	c.Backend.Disable(cap)
+	c.capabilities[cap] = false
+}
+
+func (c *StateCache) Enable(cap GLenum) {
+	if c.filter(bindingIs(c.capabilities, cap, true)) {
+		return
+	}
+	c.Backend.Enable(cap)
+	c.capabilities[cap] = true
+}
+
+func (c *StateCache) IsEnabled(cap GLenum) bool {
+	if enabled, ok := c.capabilities[cap]; ok {
+		return enabled
+	}
+	return c.Backend.IsEnabled(cap)
+}
+
+func (c *StateCache) FrontFace(mode GLenum) {
+	if c.filter(c.frontFace.is(mode)) {
+		return
+	}
+	c.Backend.FrontFace(mode)
+	c.frontFace.set(mode)
+}
+
+func (c *StateCache) Scissor(x, y GLint, width, height GLsizei) {
+	value := [4]GLint{x, y, width, height}
+	if c.filter(c.scissor.is(value)) {
+		return
+	}
+	c.Backend.Scissor(x, y, width, height)
+	c.scissor.set(value)
+}
+
+func (c *StateCache) StencilFunc(fun GLenum, ref GLint, mask GLuint) {
+	value := stencilFuncState{fun: fun, ref: ref, mask: mask}
+	if c.filter(allFaces(c.stencilFunc[:], FRONT_AND_BACK, value)) {
+		return
+	}
+	c.Backend.StencilFunc(fun, ref, mask)
+	setFaces(c.stencilFunc[:], FRONT_AND_BACK, value)
+}
+
+func (c *StateCache) StencilFuncSeparate(face, fun GLenum, ref GLint, mask GLuint) {
+	value := stencilFuncState{fun: fun, ref: ref, mask: mask}
+	if c.filter(allFaces(c.stencilFunc[:], face, value)) {
+		return
+	}
+	c.Backend.StencilFuncSeparate(face, fun, ref, mask)
+	setFaces(c.stencilFunc[:], face, value)
+}
+
+func (c *StateCache) StencilMask(mask GLuint) {
+	if c.filter(allFaces(c.stencilMask[:], FRONT_AND_BACK, mask)) {
+		return
+	}
+	c.Backend.StencilMask(mask)
+	setFaces(c.stencilMask[:], FRONT_AND_BACK, mask)
+}
+
+func (c *StateCache) StencilMaskSeparate(face GLenum, mask GLuint) {
+	if c.filter(allFaces(c.stencilMask[:], face, mask)) {
+		return
+	}
+	c.Backend.StencilMaskSeparate(face, mask)
+	setFaces(c.stencilMask[:], face, mask)
+}
+
+func (c *StateCache) StencilOp(fail, zfail, zpass GLenum) {
+	value := [3]GLenum{fail, zfail, zpass}
+	if c.filter(allFaces(c.stencilOp[:], FRONT_AND_BACK, value)) {
+		return
+	}
+	c.Backend.StencilOp(fail, zfail, zpass)
+	setFaces(c.stencilOp[:], FRONT_AND_BACK, value)
+}
+
+func (c *StateCache) StencilOpSeparate(face, fail, zfail, zpass GLenum) {
+	value := [3]GLenum{fail, zfail, zpass}
+	if c.filter(allFaces(c.stencilOp[:], face, value)) {
+		return
+	}
+	c.Backend.StencilOpSeparate(face, fail, zfail, zpass)
+	setFaces(c.stencilOp[:], face, value)
+}
+
+func (c *StateCache) UseProgram(program Program) {
+	if c.filter(c.program.is(program)) {
+		return
+	}
+	c.Backend.UseProgram(program)
+	c.program.set(program)
+}
+
+func (c *StateCache) Viewport(x, y GLint, width, height GLsizei) {
+	value := [4]GLint{x, y, width, height}
+	if c.filter(c.viewport.is(value)) {
+		return
+	}
+	c.Backend.Viewport(x, y, width, height)
+	c.viewport.set(value)
+}
+
+// cachedValue holds a piece of shadow state that may be unknown.
+type cachedValue[T comparable] struct {
+	value T
+	valid bool
+}
+
+func (v *cachedValue[T]) is(value T) bool {
+	return v.valid && v.value == value
+}
+
+func (v *cachedValue[T]) set(value T) {
+	v.value = value
+	v.valid = true
+}
+
+func (v *cachedValue[T]) reset() {
+	var zero T
+	v.value = zero
+	v.valid = false
+}
+
+type textureUnitTarget struct {
+	unit   GLenum
+	target GLenum
+}
+
+type stencilFuncState struct {
+	fun  GLenum
+	ref  GLint
+	mask GLuint
+}
+
+// bindingIs returns whether the specified key is known to have the
+// specified value.
+func bindingIs[K, V comparable](bindings map[K]V, key K, value V) bool {
+	current, ok := bindings[key]
+	return ok && current == value
+}
+
+// forgetBinding replaces all bindings to the specified deleted object with
+// the zero object, which is what WebGL does when a bound object is deleted.
+func forgetBinding[K, V comparable](bindings map[K]V, value V) {
+	var zero V
+	for key, current := range bindings {
+		if current == value {
+			bindings[key] = zero
+		}
+	}
+}
+
+// allFaces returns whether all of the faces that are affected by the
+// specified face enum are known to have the specified value.
+func allFaces[T comparable](states []cachedValue[T], face GLenum, value T) bool {
+	switch face {
+	case FRONT:
+		return states[0].is(value)
+	case BACK:
+		return states[1].is(value)
+	case FRONT_AND_BACK:
+		return states[0].is(value) && states[1].is(value)
+	default:
+		return false
+	}
+}
+
+// setFaces updates the values of all faces that are affected by the
+// specified face enum.
+func setFaces[T comparable](states []cachedValue[T], face GLenum, value T) {
+	switch face {
+	case FRONT:
+		states[0].set(value)
+	case BACK:
+		states[1].set(value)
+	case FRONT_AND_BACK:
+		states[0].set(value)
+		states[1].set(value)
+	}
+}
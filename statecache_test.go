@@ -0,0 +1,156 @@
+package wasmgl_test
+
+import (
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+	"github.com/mokiat/wasmgl/wasmgltest"
+)
+
+func TestStateCache(t *testing.T) {
+	testCases := []struct {
+		name         string
+		run          func(c *wasmgl.StateCache, b *wasmgltest.Backend)
+		function     string
+		wantCalls    int
+		wantFiltered uint64
+	}{
+		{
+			name: "repeated capability",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				c.Enable(wasmgl.DEPTH_TEST)
+				c.Enable(wasmgl.DEPTH_TEST)
+			},
+			function:     "Enable",
+			wantCalls:    1,
+			wantFiltered: 1,
+		},
+		{
+			name: "toggled capability",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				c.Enable(wasmgl.DEPTH_TEST)
+				c.Disable(wasmgl.DEPTH_TEST)
+				c.Enable(wasmgl.DEPTH_TEST)
+			},
+			function:  "Enable",
+			wantCalls: 2,
+		},
+		{
+			name: "repeated buffer binding",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				buffer := b.CreateBuffer()
+				c.BindBuffer(wasmgl.ARRAY_BUFFER, buffer)
+				c.BindBuffer(wasmgl.ARRAY_BUFFER, buffer)
+			},
+			function:     "BindBuffer",
+			wantCalls:    1,
+			wantFiltered: 1,
+		},
+		{
+			name: "element buffer after vertex array change",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				buffer := b.CreateBuffer()
+				c.BindVertexArray(b.CreateVertexArray())
+				c.BindBuffer(wasmgl.ELEMENT_ARRAY_BUFFER, buffer)
+				c.BindVertexArray(b.CreateVertexArray())
+				c.BindBuffer(wasmgl.ELEMENT_ARRAY_BUFFER, buffer)
+			},
+			function:  "BindBuffer",
+			wantCalls: 2,
+		},
+		{
+			name: "deleted buffer is unbound",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				buffer := b.CreateBuffer()
+				c.BindBuffer(wasmgl.ARRAY_BUFFER, buffer)
+				c.DeleteBuffer(buffer)
+				c.BindBuffer(wasmgl.ARRAY_BUFFER, wasmgl.NilBuffer)
+			},
+			function:     "BindBuffer",
+			wantCalls:    1,
+			wantFiltered: 1,
+		},
+		{
+			name: "texture on same unit",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				texture := b.CreateTexture()
+				c.ActiveTexture(wasmgl.TEXTURE0)
+				c.BindTexture(wasmgl.TEXTURE_2D, texture)
+				c.BindTexture(wasmgl.TEXTURE_2D, texture)
+			},
+			function:     "BindTexture",
+			wantCalls:    1,
+			wantFiltered: 1,
+		},
+		{
+			name: "texture on different units",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				texture := b.CreateTexture()
+				c.ActiveTexture(wasmgl.TEXTURE0)
+				c.BindTexture(wasmgl.TEXTURE_2D, texture)
+				c.ActiveTexture(wasmgl.TEXTURE1)
+				c.BindTexture(wasmgl.TEXTURE_2D, texture)
+			},
+			function:  "BindTexture",
+			wantCalls: 2,
+		},
+		{
+			name: "texture with unknown active unit",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				texture := b.CreateTexture()
+				c.BindTexture(wasmgl.TEXTURE_2D, texture)
+				c.BindTexture(wasmgl.TEXTURE_2D, texture)
+			},
+			function:  "BindTexture",
+			wantCalls: 2,
+		},
+		{
+			name: "framebuffer covered by both targets",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				framebuffer := b.CreateFramebuffer()
+				c.BindFramebuffer(wasmgl.FRAMEBUFFER, framebuffer)
+				c.BindFramebuffer(wasmgl.DRAW_FRAMEBUFFER, framebuffer)
+				c.BindFramebuffer(wasmgl.READ_FRAMEBUFFER, framebuffer)
+			},
+			function:     "BindFramebuffer",
+			wantCalls:    1,
+			wantFiltered: 2,
+		},
+		{
+			name: "repeated viewport",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				c.Viewport(0, 0, 800, 600)
+				c.Viewport(0, 0, 800, 600)
+				c.Viewport(0, 0, 640, 480)
+			},
+			function:     "Viewport",
+			wantCalls:    2,
+			wantFiltered: 1,
+		},
+		{
+			name: "invalidated state",
+			run: func(c *wasmgl.StateCache, b *wasmgltest.Backend) {
+				c.DepthFunc(wasmgl.LEQUAL)
+				c.Invalidate()
+				c.DepthFunc(wasmgl.LEQUAL)
+			},
+			function:  "DepthFunc",
+			wantCalls: 2,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			c := wasmgl.NewStateCache(b)
+			tc.run(c, b)
+			b.ExpectNoError(t)
+
+			if got := len(b.CallsTo(tc.function)); got != tc.wantCalls {
+				t.Errorf("got %d calls to %s, want %d", got, tc.function, tc.wantCalls)
+			}
+			if got := c.Stats().Filtered; got != tc.wantFiltered {
+				t.Errorf("Stats().Filtered = %d, want %d", got, tc.wantFiltered)
+			}
+		})
+	}
+}
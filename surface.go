@@ -0,0 +1,16 @@
+package wasmgl
+
+// limitSize scales the specified size down, preserving the aspect ratio,
+// so that it fits into the specified maximum size. Non-positive maximum
+// values do not limit the respective dimension.
+func limitSize(width, height, maxWidth, maxHeight int) (int, int) {
+	if maxWidth > 0 && width > maxWidth {
+		height = max(height*maxWidth/width, 1)
+		width = maxWidth
+	}
+	if maxHeight > 0 && height > maxHeight {
+		width = max(width*maxHeight/height, 1)
+		height = maxHeight
+	}
+	return width, height
+}
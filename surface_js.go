@@ -0,0 +1,178 @@
+//go:build js && wasm
+
+package wasmgl
+
+import (
+	"math"
+	"sync"
+	"syscall/js"
+)
+
+// SurfaceOption represents a configuration option for a Surface.
+type SurfaceOption func(s *Surface)
+
+// WithSurfaceMaxSize limits the size of the drawing buffer of a Surface.
+// If the canvas is larger, then the drawing buffer is scaled down while
+// preserving the aspect ratio and the browser stretches it to the canvas.
+// A non-positive value means that the respective dimension is not limited.
+func WithSurfaceMaxSize(maxWidth, maxHeight int) SurfaceOption {
+	return func(s *Surface) {
+		s.maxWidth = maxWidth
+		s.maxHeight = maxHeight
+	}
+}
+
+// WithSurfaceResizeHandler configures a function that is called from
+// Surface.Update whenever the drawing buffer has been resized.
+func WithSurfaceResizeHandler(handler func(width, height int)) SurfaceOption {
+	return func(s *Surface) {
+		s.onResize = handler
+	}
+}
+
+// NewSurface returns a Surface that keeps the drawing buffer of the
+// specified canvas element in sync with its displayed size in device
+// pixels. The Surface needs to be released through Release when no longer
+// needed.
+func NewSurface(htmlCanvas js.Value, opts ...SurfaceOption) *Surface {
+	s := &Surface{
+		canvas: htmlCanvas,
+		width:  htmlCanvas.Get("width").Int(),
+		height: htmlCanvas.Get("height").Int(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	ratio := devicePixelRatio()
+	s.setPending(
+		int(math.Round(htmlCanvas.Get("clientWidth").Float()*ratio)),
+		int(math.Round(htmlCanvas.Get("clientHeight").Float()*ratio)),
+	)
+	s.callback = js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) > 0 && args[0].Length() > 0 {
+			s.setPending(entryDeviceSize(args[0].Index(args[0].Length() - 1)))
+		}
+		return nil
+	})
+	s.observer = js.Global().Get("ResizeObserver").New(s.callback)
+	s.observe()
+	return s
+}
+
+// Surface keeps the drawing buffer of a canvas element in sync with the
+// size that the canvas occupies on the screen, taking devicePixelRatio into
+// account.
+//
+// Size changes are detected through a ResizeObserver and applied once
+// Update is called, which should happen at the start of each frame, so that
+// the drawing buffer does not change in the middle of a frame.
+type Surface struct {
+	canvas   js.Value
+	observer js.Value
+	callback js.Func
+
+	maxWidth  int
+	maxHeight int
+	onResize  func(width, height int)
+
+	mu            sync.Mutex
+	pendingWidth  int
+	pendingHeight int
+
+	width  int
+	height int
+}
+
+// Size returns the current size of the drawing buffer.
+func (s *Surface) Size() (width, height int) {
+	return s.width, s.height
+}
+
+// Update applies the latest observed size of the canvas to its drawing
+// buffer and returns whether the size has changed. The resize handler, if
+// configured, is called before Update returns.
+func (s *Surface) Update() bool {
+	s.mu.Lock()
+	width, height := s.pendingWidth, s.pendingHeight
+	s.mu.Unlock()
+
+	if width == s.width && height == s.height {
+		return false
+	}
+	s.canvas.Set("width", width)
+	s.canvas.Set("height", height)
+	s.width, s.height = width, height
+	if s.onResize != nil {
+		s.onResize(width, height)
+	}
+	return true
+}
+
+// Release stops observing the canvas and releases the resources of the
+// Surface.
+func (s *Surface) Release() {
+	s.observer.Call("disconnect")
+	s.callback.Release()
+}
+
+func (s *Surface) observe() {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			if _, ok := asException(recovered); !ok {
+				panic(recovered)
+			}
+			// Browsers that do not support the device-pixel-content-box
+			// option throw, in which case the content box is used together
+			// with devicePixelRatio.
+			s.observer.Call("observe", s.canvas)
+		}
+	}()
+	options := js.Global().Get("Object").New()
+	options.Set("box", "device-pixel-content-box")
+	s.observer.Call("observe", s.canvas, options)
+}
+
+func (s *Surface) setPending(width, height int) {
+	width, height = limitSize(max(width, 1), max(height, 1), s.maxWidth, s.maxHeight)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.pendingWidth = width
+	s.pendingHeight = height
+}
+
+// entryDeviceSize returns the size in device pixels from the specified
+// ResizeObserverEntry.
+func entryDeviceSize(entry js.Value) (int, int) {
+	if size, ok := firstBoxSize(entry.Get("devicePixelContentBoxSize")); ok {
+		return size.Get("inlineSize").Int(), size.Get("blockSize").Int()
+	}
+	ratio := devicePixelRatio()
+	if size, ok := firstBoxSize(entry.Get("contentBoxSize")); ok {
+		return int(math.Round(size.Get("inlineSize").Float() * ratio)), int(math.Round(size.Get("blockSize").Float() * ratio))
+	}
+	rect := entry.Get("contentRect")
+	return int(math.Round(rect.Get("width").Float() * ratio)), int(math.Round(rect.Get("height").Float() * ratio))
+}
+
+// firstBoxSize returns the first ResizeObserverSize of the specified value.
+// Older browsers report a single size instead of an array.
+func firstBoxSize(sizes js.Value) (js.Value, bool) {
+	if sizes.Type() != js.TypeObject {
+		return js.Undefined(), false
+	}
+	if sizes.Get("length").Type() != js.TypeNumber {
+		return sizes, true
+	}
+	if sizes.Length() == 0 {
+		return js.Undefined(), false
+	}
+	return sizes.Index(0), true
+}
+
+func devicePixelRatio() float64 {
+	ratio := js.Global().Get("devicePixelRatio")
+	if ratio.Type() != js.TypeNumber || ratio.Float() <= 0 {
+		return 1.0
+	}
+	return ratio.Float()
+}
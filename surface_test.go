@@ -0,0 +1,29 @@
+package wasmgl
+
+import "testing"
+
+func TestLimitSize(t *testing.T) {
+	testCases := []struct {
+		name                  string
+		width, height         int
+		maxWidth, maxHeight   int
+		wantWidth, wantHeight int
+	}{
+		{"no limits", 1920, 1080, 0, 0, 1920, 1080},
+		{"within limits", 800, 600, 1024, 1024, 800, 600},
+		{"width limited", 2000, 1000, 1000, 0, 1000, 500},
+		{"height limited", 1000, 2000, 0, 1000, 500, 1000},
+		{"both limited", 4000, 2000, 1000, 400, 800, 400},
+		{"exact limits", 1024, 768, 1024, 768, 1024, 768},
+		{"minimum size", 10000, 1, 100, 0, 100, 1},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			width, height := limitSize(tc.width, tc.height, tc.maxWidth, tc.maxHeight)
+			if width != tc.wantWidth || height != tc.wantHeight {
+				t.Errorf("limitSize(%d, %d, %d, %d) = (%d, %d), want (%d, %d)",
+					tc.width, tc.height, tc.maxWidth, tc.maxHeight, width, height, tc.wantWidth, tc.wantHeight)
+			}
+		})
+	}
+}
@@ -0,0 +1,619 @@
+package wasmgl
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+)
+
+// NewTracker returns a Tracker that forwards calls to the specified
+// delegate Backend.
+func NewTracker(delegate Backend) *Tracker {
+	return &Tracker{
+		Backend: delegate,
+
+		objects: make(map[*object]*TrackedObject),
+
+		activeTexture:  TEXTURE0,
+		buffers:        make(map[GLenum]*object),
+		elementBuffers: make(map[*object]*object),
+		textures:       make(map[textureUnitTarget]*object),
+	}
+}
+
+var _ Backend = (*Tracker)(nil)
+
+// Tracker is a Backend that keeps a registry of all WebGL objects that
+// have been created through it and have not been deleted yet. For each
+// object, the Go call stack of its creation and an estimate of the memory
+// that it occupies are recorded.
+//
+// A Tracker is useful for finding resource leaks, for example by checking
+// the live objects at the end of a test.
+//
+// The Tracker needs to see all bind calls in order to attribute storage
+// allocations to the correct objects. If code outside of this package
+// changes bindings, then allocations might be missed.
+type Tracker struct {
+	// Backend is the delegate Backend that calls are forwarded to.
+	Backend
+
+	sequence uint64
+	objects  map[*object]*TrackedObject
+
+	activeTexture  GLenum
+	buffers        map[GLenum]*object
+	renderbuffer   *object
+	vertexArray    *object
+	elementBuffers map[*object]*object
+	textures       map[textureUnitTarget]*object
+}
+
+// TrackedObject holds information about a live WebGL object.
+type TrackedObject struct {
+
+	// Type is the name of the handle type of the object (e.g. "Buffer").
+	Type string
+
+	// Handle is the handle of the object (e.g. a Buffer value).
+	Handle any
+
+	// Label is the debug label of the object at the time the TrackedObject
+	// was obtained, or an empty string if there is none.
+	Label string
+
+	// Size is the estimated number of bytes that the object occupies in GPU
+	// memory.
+	Size int
+
+	// Format is the internal format of the storage of the object, for
+	// textures and renderbuffers. It is zero for other objects or if no
+	// storage has been allocated.
+	Format GLenum
+
+	// Stack holds the Go call stack at the time the object was created.
+	Stack []runtime.Frame
+
+	sequence uint64
+	obj      *object
+	images   map[imageKey]textureImage
+}
+
+// imageKey identifies a single image of a texture that has been allocated
+// through a TexImage* call.
+type imageKey struct {
+	target GLenum
+	level  GLint
+}
+
+// textureImage describes the dimensions and estimated size of a single
+// image of a texture.
+type textureImage struct {
+	width  GLsizei
+	height GLsizei
+	depth  GLsizei
+	size   int
+}
+
+// Caller returns the file and line of the code that created the object.
+func (o TrackedObject) Caller() string {
+	if len(o.Stack) == 0 {
+		return "unknown"
+	}
+	return fmt.Sprintf("%s:%d", o.Stack[0].File, o.Stack[0].Line)
+}
+
+// TrackedGroup summarizes the live objects of a given type that have been
+// created from the same call site.
+type TrackedGroup struct {
+
+	// Type is the name of the handle type of the objects.
+	Type string
+
+	// Caller is the file and line of the code that created the objects.
+	Caller string
+
+	// Count is the number of live objects.
+	Count int
+
+	// Size is the total estimated size of the objects in bytes.
+	Size int
+
+	// Labels holds the distinct debug labels of the objects, sorted. Objects
+	// without labels are not represented.
+	Labels []string
+}
+
+// LiveObjects returns all objects that have been created and not yet
+// deleted, in the order of their creation.
+func (t *Tracker) LiveObjects() []TrackedObject {
+	result := make([]TrackedObject, 0, len(t.objects))
+	for _, obj := range t.objects {
+		tracked := *obj
+		tracked.Label = obj.obj.getLabel()
+		result = append(result, tracked)
+	}
+	slices.SortFunc(result, func(a, b TrackedObject) int {
+		return cmp.Compare(a.sequence, b.sequence)
+	})
+	return result
+}
+
+// LiveCount returns the number of live objects of the specified handle type
+// (e.g. "Texture"). If typeName is empty, then all live objects are
+// counted.
+func (t *Tracker) LiveCount(typeName string) int {
+	count := 0
+	for _, obj := range t.objects {
+		if typeName == "" || obj.Type == typeName {
+			count++
+		}
+	}
+	return count
+}
+
+// Report returns the live objects grouped by type and call site, sorted by
+// type and then by call site.
+func (t *Tracker) Report() []TrackedGroup {
+	type groupKey struct {
+		typeName string
+		caller   string
+	}
+	groups := make(map[groupKey]*TrackedGroup)
+	for _, obj := range t.objects {
+		key := groupKey{typeName: obj.Type, caller: obj.Caller()}
+		group, ok := groups[key]
+		if !ok {
+			group = &TrackedGroup{Type: key.typeName, Caller: key.caller}
+			groups[key] = group
+		}
+		group.Count++
+		group.Size += obj.Size
+		if label := obj.obj.getLabel(); label != "" {
+			group.Labels = append(group.Labels, label)
+		}
+	}
+	result := make([]TrackedGroup, 0, len(groups))
+	for _, group := range groups {
+		slices.Sort(group.Labels)
+		group.Labels = slices.Compact(group.Labels)
+		result = append(result, *group)
+	}
+	slices.SortFunc(result, func(a, b TrackedGroup) int {
+		return cmp.Or(cmp.Compare(a.Type, b.Type), cmp.Compare(a.Caller, b.Caller))
+	})
+	return result
+}
+
+// MemoryUsage holds the estimated GPU memory usage of live objects, in
+// bytes.
+type MemoryUsage struct {
+
+	// Total is the total usage of all objects.
+	Total int
+
+	// ByType holds the usage per handle type name (e.g. "Texture").
+	ByType map[string]int
+
+	// ByFormat holds the usage of textures and renderbuffers per internal
+	// format.
+	ByFormat map[GLenum]int
+
+	// ByLabel holds the usage per debug label. Objects without labels are
+	// not included.
+	ByLabel map[string]int
+}
+
+// MemoryUsage returns the estimated GPU memory usage of all live objects.
+//
+// The estimates are based on the sizes of the allocations (e.g. BufferData,
+// TexStorage2D, TexImage2D or RenderbufferStorage) and a table of format
+// sizes, taking mipmap levels, cube map faces and samples into account.
+// Browsers and drivers are free to use more memory (e.g. due to padding or
+// alignment).
+func (t *Tracker) MemoryUsage() MemoryUsage {
+	usage := MemoryUsage{
+		ByType:   make(map[string]int),
+		ByFormat: make(map[GLenum]int),
+		ByLabel:  make(map[string]int),
+	}
+	for _, obj := range t.objects {
+		usage.Total += obj.Size
+		usage.ByType[obj.Type] += obj.Size
+		if obj.Format != 0 {
+			usage.ByFormat[obj.Format] += obj.Size
+		}
+		if label := obj.obj.getLabel(); label != "" {
+			usage.ByLabel[label] += obj.Size
+		}
+	}
+	return usage
+}
+
+// WriteReport writes a human-readable form of Report to the specified
+// writer.
+func (t *Tracker) WriteReport(w io.Writer) error {
+	for _, group := range t.Report() {
+		labels := ""
+		if len(group.Labels) > 0 {
+			labels = fmt.Sprintf("\tlabels=%q", group.Labels)
+		}
+		if _, err := fmt.Fprintf(w, "%s\tcount=%d\tsize=%d\t%s%s\n", group.Type, group.Count, group.Size, group.Caller, labels); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *Tracker) track(typeName string, obj *object, handle any) {
+	if obj == nil {
+		return
+	}
+	t.sequence++
+	t.objects[obj] = &TrackedObject{
+		Type:     typeName,
+		Handle:   handle,
+		Stack:    callerStack(),
+		sequence: t.sequence,
+		obj:      obj,
+	}
+}
+
+func (t *Tracker) untrack(obj *object) {
+	if obj == nil {
+		return
+	}
+	delete(t.objects, obj)
+	for target, buffer := range t.buffers {
+		if buffer == obj {
+			delete(t.buffers, target)
+		}
+	}
+	for vertexArray, buffer := range t.elementBuffers {
+		if vertexArray == obj || buffer == obj {
+			delete(t.elementBuffers, vertexArray)
+		}
+	}
+	for binding, texture := range t.textures {
+		if texture == obj {
+			delete(t.textures, binding)
+		}
+	}
+	if t.vertexArray == obj {
+		t.vertexArray = nil
+	}
+	if t.renderbuffer == obj {
+		t.renderbuffer = nil
+	}
+}
+
+func (t *Tracker) setSize(obj *object, size int) {
+	if tracked, ok := t.objects[obj]; ok {
+		tracked.Size = size
+	}
+}
+
+func (t *Tracker) setStorage(obj *object, format GLenum, size int) {
+	if tracked, ok := t.objects[obj]; ok {
+		tracked.Size = size
+		tracked.Format = format
+		tracked.images = nil
+	}
+}
+
+// setImage records a single image of the texture that is bound for the
+// specified image target (e.g. TEXTURE_CUBE_MAP_POSITIVE_X).
+func (t *Tracker) setImage(target GLenum, level GLint, format GLenum, width, height, depth GLsizei, size int) {
+	texture := t.boundTexture(imageBindingTarget(target))
+	tracked, ok := t.objects[texture]
+	if !ok {
+		return
+	}
+	if tracked.images == nil {
+		tracked.images = make(map[imageKey]textureImage)
+	}
+	tracked.images[imageKey{target: target, level: level}] = textureImage{
+		width:  width,
+		height: height,
+		depth:  depth,
+		size:   size,
+	}
+	tracked.Format = format
+	tracked.updateImagesSize()
+}
+
+// setMipmaps records the mipmap levels that GenerateMipmap derives from the
+// base level images of the texture that is bound for the specified target.
+// Textures with immutable storage have no recorded images, since their
+// size already accounts for all levels.
+func (t *Tracker) setMipmaps(target GLenum) {
+	tracked, ok := t.objects[t.boundTexture(target)]
+	if !ok || len(tracked.images) == 0 {
+		return
+	}
+	for key, base := range tracked.images {
+		if key.level != 0 {
+			continue
+		}
+		width, height, depth := base.width, base.height, base.depth
+		for level := GLint(1); width > 1 || height > 1 || (target == TEXTURE_3D && depth > 1); level++ {
+			width = max(width/2, 1)
+			height = max(height/2, 1)
+			if target == TEXTURE_3D {
+				depth = max(depth/2, 1)
+			}
+			tracked.images[imageKey{target: key.target, level: level}] = textureImage{
+				width:  width,
+				height: height,
+				depth:  depth,
+				size:   mipmapSize(base, width, height, depth),
+			}
+		}
+	}
+	tracked.updateImagesSize()
+}
+
+func (o *TrackedObject) updateImagesSize() {
+	o.Size = 0
+	for _, image := range o.images {
+		o.Size += image.size
+	}
+}
+
+// mipmapSize returns the estimated number of bytes of a mipmap level with
+// the specified dimensions, scaled from the size of the base image.
+func mipmapSize(base textureImage, width, height, depth GLsizei) int {
+	baseTexels := int(base.width) * int(base.height) * int(base.depth)
+	if baseTexels == 0 {
+		return 0
+	}
+	return base.size * int(width) * int(height) * int(depth) / baseTexels
+}
+
+func (t *Tracker) boundTexture(target GLenum) *object {
+	return t.textures[textureUnitTarget{unit: t.activeTexture, target: target}]
+}
+
+// imageBindingTarget returns the texture target that is bound for the
+// specified image target.
+func imageBindingTarget(target GLenum) GLenum {
+	if target >= TEXTURE_CUBE_MAP_POSITIVE_X && target <= TEXTURE_CUBE_MAP_NEGATIVE_Z {
+		return TEXTURE_CUBE_MAP
+	}
+	return target
+}
+
+// imageSize returns the estimated number of bytes of a single image with
+// the specified format and dimensions.
+func imageSize(internalFormat GLenum, width, height, depth GLsizei) int {
+	return int(width) * int(height) * int(depth) * texelSize(internalFormat)
+}
+
+func (t *Tracker) boundBuffer(target GLenum) *object {
+	if target == ELEMENT_ARRAY_BUFFER {
+		return t.elementBuffers[t.vertexArray]
+	}
+	return t.buffers[target]
+}
+
+func (t *Tracker) bindBuffer(target GLenum, buffer *object) {
+	if target == ELEMENT_ARRAY_BUFFER {
+		t.elementBuffers[t.vertexArray] = buffer
+	} else {
+		t.buffers[target] = buffer
+	}
+}
+
+func (t *Tracker) ActiveTexture(texture GLenum) {
+	t.Backend.ActiveTexture(texture)
+	t.activeTexture = texture
+}
+
+func (t *Tracker) BindBuffer(target GLenum, buffer Buffer) {
+	t.Backend.BindBuffer(target, buffer)
+	t.bindBuffer(target, buffer.obj)
+}
+
+func (t *Tracker) BindBufferBase(target GLenum, index GLuint, buffer Buffer) {
+	t.Backend.BindBufferBase(target, index, buffer)
+	t.bindBuffer(target, buffer.obj)
+}
+
+func (t *Tracker) BindBufferRange(target GLenum, index GLuint, buffer Buffer, offset GLintptr, size GLsizeiptr) {
+	t.Backend.BindBufferRange(target, index, buffer, offset, size)
+	t.bindBuffer(target, buffer.obj)
+}
+
+func (t *Tracker) BindRenderbuffer(target GLenum, renderbuffer Renderbuffer) {
+	t.Backend.BindRenderbuffer(target, renderbuffer)
+	t.renderbuffer = renderbuffer.obj
+}
+
+func (t *Tracker) BindTexture(target GLenum, texture Texture) {
+	t.Backend.BindTexture(target, texture)
+	t.textures[textureUnitTarget{unit: t.activeTexture, target: target}] = texture.obj
+}
+
+func (t *Tracker) BindVertexArray(array VertexArray) {
+	t.Backend.BindVertexArray(array)
+	t.vertexArray = array.obj
+}
+
+func (t *Tracker) BufferData(target GLenum, size GLsizeiptr, data []byte, usage GLenum) {
+	t.Backend.BufferData(target, size, data, usage)
+	if data != nil {
+		size = GLsizeiptr(len(data))
+	}
+	t.setSize(t.boundBuffer(target), int(size))
+}
+
+func (t *Tracker) CompressedTexImage2D(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, data []byte) {
+	t.Backend.CompressedTexImage2D(target, level, internalFormat, width, height, border, data)
+	t.setImage(target, level, internalFormat, width, height, 1, len(data))
+}
+
+func (t *Tracker) CompressedTexImage2DOffset(target GLenum, level GLint, internalFormat GLenum, width, height GLsizei, border GLint, imageSize GLsizei, offset GLintptr) {
+	t.Backend.CompressedTexImage2DOffset(target, level, internalFormat, width, height, border, imageSize, offset)
+	t.setImage(target, level, internalFormat, width, height, 1, int(imageSize))
+}
+
+func (t *Tracker) CompressedTexImage3D(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, data []byte) {
+	t.Backend.CompressedTexImage3D(target, level, internalFormat, width, height, depth, border, data)
+	t.setImage(target, level, internalFormat, width, height, depth, len(data))
+}
+
+func (t *Tracker) CompressedTexImage3DOffset(target GLenum, level GLint, internalFormat GLenum, width, height, depth GLsizei, border GLint, imageSize GLsizei, offset GLintptr) {
+	t.Backend.CompressedTexImage3DOffset(target, level, internalFormat, width, height, depth, border, imageSize, offset)
+	t.setImage(target, level, internalFormat, width, height, depth, int(imageSize))
+}
+
+func (t *Tracker) CopyTexImage2D(target GLenum, level GLint, internalFormat GLenum, x, y GLint, width, height GLsizei, border GLint) {
+	t.Backend.CopyTexImage2D(target, level, internalFormat, x, y, width, height, border)
+	t.setImage(target, level, internalFormat, width, height, 1, imageSize(internalFormat, width, height, 1))
+}
+
+func (t *Tracker) CreateBuffer() Buffer {
+	result := t.Backend.CreateBuffer()
+	t.track("Buffer", result.obj, result)
+	return result
+}
+
+func (t *Tracker) CreateFramebuffer() Framebuffer {
+	result := t.Backend.CreateFramebuffer()
+	t.track("Framebuffer", result.obj, result)
+	return result
+}
+
+func (t *Tracker) CreateProgram() Program {
+	result := t.Backend.CreateProgram()
+	t.track("Program", result.obj, result)
+	return result
+}
+
+func (t *Tracker) CreateRenderbuffer() Renderbuffer {
+	result := t.Backend.CreateRenderbuffer()
+	t.track("Renderbuffer", result.obj, result)
+	return result
+}
+
+func (t *Tracker) CreateSampler() Sampler {
+	result := t.Backend.CreateSampler()
+	t.track("Sampler", result.obj, result)
+	return result
+}
+
+func (t *Tracker) CreateShader(shaderType GLenum) Shader {
+	result := t.Backend.CreateShader(shaderType)
+	t.track("Shader", result.obj, result)
+	return result
+}
+
+func (t *Tracker) CreateTexture() Texture {
+	result := t.Backend.CreateTexture()
+	t.track("Texture", result.obj, result)
+	return result
+}
+
+func (t *Tracker) CreateVertexArray() VertexArray {
+	result := t.Backend.CreateVertexArray()
+	t.track("VertexArray", result.obj, result)
+	return result
+}
+
+func (t *Tracker) DeleteBuffer(buffer Buffer) {
+	t.Backend.DeleteBuffer(buffer)
+	t.untrack(buffer.obj)
+}
+
+func (t *Tracker) DeleteFramebuffer(framebuffer Framebuffer) {
+	t.Backend.DeleteFramebuffer(framebuffer)
+	t.untrack(framebuffer.obj)
+}
+
+func (t *Tracker) DeleteProgram(program Program) {
+	t.Backend.DeleteProgram(program)
+	t.untrack(program.obj)
+}
+
+func (t *Tracker) DeleteRenderbuffer(renderbuffer Renderbuffer) {
+	t.Backend.DeleteRenderbuffer(renderbuffer)
+	t.untrack(renderbuffer.obj)
+}
+
+func (t *Tracker) DeleteSampler(sampler Sampler) {
+	t.Backend.DeleteSampler(sampler)
+	t.untrack(sampler.obj)
+}
+
+func (t *Tracker) DeleteShader(shader Shader) {
+	t.Backend.DeleteShader(shader)
+	t.untrack(shader.obj)
+}
+
+func (t *Tracker) DeleteSync(sync Sync) {
+	t.Backend.DeleteSync(sync)
+	t.untrack(sync.obj)
+}
+
+func (t *Tracker) DeleteTexture(texture Texture) {
+	t.Backend.DeleteTexture(texture)
+	t.untrack(texture.obj)
+}
+
+func (t *Tracker) DeleteVertexArray(array VertexArray) {
+	t.Backend.DeleteVertexArray(array)
+	t.untrack(array.obj)
+}
+
+func (t *Tracker) FenceSync(condition GLenum, flags GLbitfield) Sync {
+	result := t.Backend.FenceSync(condition, flags)
+	t.track("Sync", result.obj, result)
+	return result
+}
+
+func (t *Tracker) GenerateMipmap(target GLenum) {
+	t.Backend.GenerateMipmap(target)
+	t.setMipmaps(target)
+}
+
+func (t *Tracker) RenderbufferStorage(target, internalFormat GLenum, width, height GLsizei) {
+	t.Backend.RenderbufferStorage(target, internalFormat, width, height)
+	t.setStorage(t.renderbuffer, internalFormat, imageSize(internalFormat, width, height, 1))
+}
+
+func (t *Tracker) RenderbufferStorageMultisample(target GLenum, samples GLsizei, internalFormat GLenum, width, height GLsizei) {
+	t.Backend.RenderbufferStorageMultisample(target, samples, internalFormat, width, height)
+	t.setStorage(t.renderbuffer, internalFormat, int(max(samples, 1))*imageSize(internalFormat, width, height, 1))
+}
+
+func (t *Tracker) TexImage2D(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, data []byte) {
+	t.Backend.TexImage2D(target, level, internalFormat, width, height, border, format, dtype, data)
+	t.setImage(target, level, GLenum(internalFormat), width, height, 1, imageSize(GLenum(internalFormat), width, height, 1))
+}
+
+func (t *Tracker) TexImage2DOffset(target GLenum, level, internalFormat GLint, width, height GLsizei, border GLint, format, dtype GLenum, offset GLintptr) {
+	t.Backend.TexImage2DOffset(target, level, internalFormat, width, height, border, format, dtype, offset)
+	t.setImage(target, level, GLenum(internalFormat), width, height, 1, imageSize(GLenum(internalFormat), width, height, 1))
+}
+
+func (t *Tracker) TexImage3D(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, data []byte) {
+	t.Backend.TexImage3D(target, level, internalFormat, width, height, depth, border, format, dtype, data)
+	t.setImage(target, level, GLenum(internalFormat), width, height, depth, imageSize(GLenum(internalFormat), width, height, depth))
+}
+
+func (t *Tracker) TexImage3DOffset(target GLenum, level, internalFormat GLint, width, height, depth GLsizei, border GLint, format, dtype GLenum, offset GLintptr) {
+	t.Backend.TexImage3DOffset(target, level, internalFormat, width, height, depth, border, format, dtype, offset)
+	t.setImage(target, level, GLenum(internalFormat), width, height, depth, imageSize(GLenum(internalFormat), width, height, depth))
+}
+
+func (t *Tracker) TexStorage2D(target GLenum, levels GLsizei, internalFormat GLenum, width, height GLsizei) {
+	t.Backend.TexStorage2D(target, levels, internalFormat, width, height)
+	t.setStorage(t.boundTexture(target), internalFormat, textureStorageSize(target, levels, internalFormat, width, height, 1))
+}
+
+func (t *Tracker) TexStorage3D(target GLenum, levels GLsizei, internalFormat GLenum, width, height, depth GLsizei) {
+	t.Backend.TexStorage3D(target, levels, internalFormat, width, height, depth)
+	t.setStorage(t.boundTexture(target), internalFormat, textureStorageSize(target, levels, internalFormat, width, height, depth))
+}
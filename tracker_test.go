@@ -0,0 +1,254 @@
+package wasmgl_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+	"github.com/mokiat/wasmgl/wasmgltest"
+)
+
+func TestTrackerLiveCount(t *testing.T) {
+	testCases := []struct {
+		name string
+		run  func(tr *wasmgl.Tracker)
+		want map[string]int
+	}{
+		{
+			name: "no objects",
+			run:  func(tr *wasmgl.Tracker) {},
+			want: map[string]int{"": 0, "Buffer": 0},
+		},
+		{
+			name: "created objects",
+			run: func(tr *wasmgl.Tracker) {
+				tr.CreateBuffer()
+				tr.CreateBuffer()
+				tr.CreateTexture()
+			},
+			want: map[string]int{"": 3, "Buffer": 2, "Texture": 1, "Program": 0},
+		},
+		{
+			name: "deleted objects",
+			run: func(tr *wasmgl.Tracker) {
+				tr.DeleteBuffer(tr.CreateBuffer())
+				tr.CreateBuffer()
+				tr.DeleteTexture(tr.CreateTexture())
+			},
+			want: map[string]int{"": 1, "Buffer": 1, "Texture": 0},
+		},
+		{
+			name: "deleted through another handle",
+			run: func(tr *wasmgl.Tracker) {
+				shader := tr.CreateShader(wasmgl.VERTEX_SHADER)
+				tr.DeleteShader(wasmgl.NewShader(shader.Value()))
+			},
+			want: map[string]int{"": 0, "Shader": 0},
+		},
+		{
+			name: "fences",
+			run: func(tr *wasmgl.Tracker) {
+				tr.FenceSync(wasmgl.SYNC_GPU_COMMANDS_COMPLETE, 0)
+			},
+			want: map[string]int{"": 1, "Sync": 1},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			tr := wasmgl.NewTracker(b)
+			tc.run(tr)
+			b.ExpectNoError(t)
+
+			for typeName, want := range tc.want {
+				if got := tr.LiveCount(typeName); got != want {
+					t.Errorf("LiveCount(%q) = %d, want %d", typeName, got, want)
+				}
+			}
+			if got, want := len(tr.LiveObjects()), tr.LiveCount(""); got != want {
+				t.Errorf("got %d LiveObjects, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestTrackerReport(t *testing.T) {
+	tr := wasmgl.NewTracker(wasmgltest.NewBackend())
+	for range 2 {
+		tr.CreateBuffer()
+	}
+	tr.CreateTexture()
+
+	report := tr.Report()
+	if len(report) != 2 {
+		t.Fatalf("got %d groups, want 2", len(report))
+	}
+	wantGroups := []struct {
+		typeName string
+		count    int
+	}{
+		{"Buffer", 2},
+		{"Texture", 1},
+	}
+	for i, want := range wantGroups {
+		group := report[i]
+		if group.Type != want.typeName || group.Count != want.count {
+			t.Errorf("group %d = %s x%d, want %s x%d", i, group.Type, group.Count, want.typeName, want.count)
+		}
+		if !strings.Contains(group.Caller, "tracker_test.go") {
+			t.Errorf("group %d caller = %q, want location in tracker_test.go", i, group.Caller)
+		}
+	}
+}
+
+func TestTrackerMemoryUsage(t *testing.T) {
+	texImage := func(tr *wasmgl.Tracker, target wasmgl.GLenum, width, height wasmgl.GLsizei) {
+		tr.TexImage2D(target, 0, wasmgl.RGBA8, width, height, 0, wasmgl.RGBA, wasmgl.UNSIGNED_BYTE, nil)
+	}
+	testCases := []struct {
+		name       string
+		run        func(tr *wasmgl.Tracker)
+		wantType   string
+		wantFormat wasmgl.GLenum
+		want       int
+	}{
+		{
+			name: "buffer data",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindBuffer(wasmgl.ARRAY_BUFFER, tr.CreateBuffer())
+				tr.BufferData(wasmgl.ARRAY_BUFFER, 256, nil, wasmgl.STATIC_DRAW)
+			},
+			wantType: "Buffer",
+			want:     256,
+		},
+		{
+			name: "texture storage with mipmap levels",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindTexture(wasmgl.TEXTURE_2D, tr.CreateTexture())
+				tr.TexStorage2D(wasmgl.TEXTURE_2D, 3, wasmgl.RGBA8, 4, 4)
+			},
+			wantType:   "Texture",
+			wantFormat: wasmgl.RGBA8,
+			want:       64 + 16 + 4,
+		},
+		{
+			name: "cube map storage",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindTexture(wasmgl.TEXTURE_CUBE_MAP, tr.CreateTexture())
+				tr.TexStorage2D(wasmgl.TEXTURE_CUBE_MAP, 1, wasmgl.RGBA8, 4, 4)
+			},
+			wantType:   "Texture",
+			wantFormat: wasmgl.RGBA8,
+			want:       6 * 64,
+		},
+		{
+			name: "replaced image",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindTexture(wasmgl.TEXTURE_2D, tr.CreateTexture())
+				texImage(tr, wasmgl.TEXTURE_2D, 8, 8)
+				texImage(tr, wasmgl.TEXTURE_2D, 4, 4)
+			},
+			wantType:   "Texture",
+			wantFormat: wasmgl.RGBA8,
+			want:       64,
+		},
+		{
+			name: "cube map faces",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindTexture(wasmgl.TEXTURE_CUBE_MAP, tr.CreateTexture())
+				texImage(tr, wasmgl.TEXTURE_CUBE_MAP_POSITIVE_X, 4, 4)
+				texImage(tr, wasmgl.TEXTURE_CUBE_MAP_NEGATIVE_X, 4, 4)
+			},
+			wantType:   "Texture",
+			wantFormat: wasmgl.RGBA8,
+			want:       2 * 64,
+		},
+		{
+			name: "generated mipmaps",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindTexture(wasmgl.TEXTURE_2D, tr.CreateTexture())
+				texImage(tr, wasmgl.TEXTURE_2D, 4, 4)
+				tr.GenerateMipmap(wasmgl.TEXTURE_2D)
+			},
+			wantType:   "Texture",
+			wantFormat: wasmgl.RGBA8,
+			want:       64 + 16 + 4,
+		},
+		{
+			name: "generated mipmaps of texture storage",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindTexture(wasmgl.TEXTURE_2D, tr.CreateTexture())
+				tr.TexStorage2D(wasmgl.TEXTURE_2D, 3, wasmgl.RGBA8, 4, 4)
+				tr.GenerateMipmap(wasmgl.TEXTURE_2D)
+			},
+			wantType:   "Texture",
+			wantFormat: wasmgl.RGBA8,
+			want:       64 + 16 + 4,
+		},
+		{
+			name: "generated mipmaps of non-square image",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindTexture(wasmgl.TEXTURE_2D, tr.CreateTexture())
+				texImage(tr, wasmgl.TEXTURE_2D, 5, 3)
+				tr.GenerateMipmap(wasmgl.TEXTURE_2D)
+			},
+			wantType:   "Texture",
+			wantFormat: wasmgl.RGBA8,
+			want:       60 + 8 + 4,
+		},
+		{
+			name: "generated cube map mipmaps",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindTexture(wasmgl.TEXTURE_CUBE_MAP, tr.CreateTexture())
+				texImage(tr, wasmgl.TEXTURE_CUBE_MAP_POSITIVE_X, 4, 4)
+				texImage(tr, wasmgl.TEXTURE_CUBE_MAP_NEGATIVE_X, 4, 4)
+				tr.GenerateMipmap(wasmgl.TEXTURE_CUBE_MAP)
+			},
+			wantType:   "Texture",
+			wantFormat: wasmgl.RGBA8,
+			want:       2 * (64 + 16 + 4),
+		},
+		{
+			name: "multisample renderbuffer",
+			run: func(tr *wasmgl.Tracker) {
+				tr.BindRenderbuffer(wasmgl.RENDERBUFFER, tr.CreateRenderbuffer())
+				tr.RenderbufferStorageMultisample(wasmgl.RENDERBUFFER, 4, wasmgl.DEPTH24_STENCIL8, 8, 8)
+			},
+			wantType:   "Renderbuffer",
+			wantFormat: wasmgl.DEPTH24_STENCIL8,
+			want:       4 * 256,
+		},
+		{
+			name: "deleted texture",
+			run: func(tr *wasmgl.Tracker) {
+				texture := tr.CreateTexture()
+				tr.BindTexture(wasmgl.TEXTURE_2D, texture)
+				texImage(tr, wasmgl.TEXTURE_2D, 4, 4)
+				tr.DeleteTexture(texture)
+			},
+			wantType: "Texture",
+			want:     0,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			tr := wasmgl.NewTracker(b)
+			tc.run(tr)
+			b.ExpectNoError(t)
+
+			usage := tr.MemoryUsage()
+			if usage.Total != tc.want {
+				t.Errorf("Total = %d, want %d", usage.Total, tc.want)
+			}
+			if got := usage.ByType[tc.wantType]; got != tc.want {
+				t.Errorf("ByType[%q] = %d, want %d", tc.wantType, got, tc.want)
+			}
+			if tc.wantFormat != 0 {
+				if got := usage.ByFormat[tc.wantFormat]; got != tc.want {
+					t.Errorf("ByFormat[%s] = %d, want %d", wasmgl.EnumName(tc.wantFormat, wasmgl.EnumCategoryAny), got, tc.want)
+				}
+			}
+		})
+	}
+}
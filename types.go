@@ -1,8 +1,11 @@
-//go:build js && wasm
-
 package wasmgl
 
-import "syscall/js"
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
 
 type (
 	// GLenum represents the GLenum type from the specification.
@@ -47,39 +50,180 @@ type (
 )
 
 // NilBuffer equals the zero Buffer.
-var NilBuffer = Buffer(js.Null())
+var NilBuffer = Buffer{}
 
 // Buffer represents the WebGLBuffer type from the specification.
-type Buffer js.Value
+type Buffer struct {
+	obj *object
+}
 
 // IsValid returns whether this Buffer is different from the zero Buffer
 // or an unspecified Buffer.
 func (b Buffer) IsValid() bool {
-	return isSpecified(js.Value(b))
+	return b.obj != nil
+}
+
+// NewBuffer returns a Buffer that wraps the specified backend-specific value.
+// The result equals the zero Buffer if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewBuffer(value any) Buffer {
+	return Buffer{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this Buffer.
+func (b Buffer) Value() any {
+	return b.obj.get()
+}
+
+// ID returns a number that uniquely identifies this Buffer within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (b Buffer) ID() uint64 {
+	return b.obj.getID()
+}
+
+// Equal returns whether this Buffer is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (b Buffer) Equal(other Buffer) bool {
+	return b.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this Buffer, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid Buffer has no effect.
+func (b Buffer) SetLabel(label string) {
+	b.obj.setLabel(label)
+}
+
+// Label returns the debug label of this Buffer or an empty string if no
+// label has been set.
+func (b Buffer) Label() string {
+	return b.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this Buffer
+// (e.g. Buffer#12("name")).
+func (b Buffer) String() string {
+	return b.obj.format("Buffer")
 }
 
 // NilFramebuffer equals the zero Framebuffer.
-var NilFramebuffer = Framebuffer(js.Null())
+var NilFramebuffer = Framebuffer{}
 
 // Framebuffer represents the WebGLFramebuffer type from the specification.
-type Framebuffer js.Value
+type Framebuffer struct {
+	obj *object
+}
 
 // IsValid returns whether this Framebuffer is different from the zero
 // Framebuffer or an unspecified Framebuffer.
 func (f Framebuffer) IsValid() bool {
-	return isSpecified(js.Value(f))
+	return f.obj != nil
+}
+
+// NewFramebuffer returns a Framebuffer that wraps the specified backend-specific value.
+// The result equals the zero Framebuffer if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewFramebuffer(value any) Framebuffer {
+	return Framebuffer{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this Framebuffer.
+func (f Framebuffer) Value() any {
+	return f.obj.get()
+}
+
+// ID returns a number that uniquely identifies this Framebuffer within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (f Framebuffer) ID() uint64 {
+	return f.obj.getID()
+}
+
+// Equal returns whether this Framebuffer is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (f Framebuffer) Equal(other Framebuffer) bool {
+	return f.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this Framebuffer, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid Framebuffer has no effect.
+func (f Framebuffer) SetLabel(label string) {
+	f.obj.setLabel(label)
+}
+
+// Label returns the debug label of this Framebuffer or an empty string if no
+// label has been set.
+func (f Framebuffer) Label() string {
+	return f.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this Framebuffer
+// (e.g. Framebuffer#12("name")).
+func (f Framebuffer) String() string {
+	return f.obj.format("Framebuffer")
 }
 
 // NilProgram equals the zero Program.
-var NilProgram = Program(js.Null())
+var NilProgram = Program{}
 
 // Program represents the WebGLProgram type from the specification.
-type Program js.Value
+type Program struct {
+	obj *object
+}
 
 // IsValid returns whether this Program is different from the zero Program or
 // an unspecified Program.
 func (p Program) IsValid() bool {
-	return isSpecified(js.Value(p))
+	return p.obj != nil
+}
+
+// NewProgram returns a Program that wraps the specified backend-specific value.
+// The result equals the zero Program if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewProgram(value any) Program {
+	return Program{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this Program.
+func (p Program) Value() any {
+	return p.obj.get()
+}
+
+// ID returns a number that uniquely identifies this Program within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (p Program) ID() uint64 {
+	return p.obj.getID()
+}
+
+// Equal returns whether this Program is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (p Program) Equal(other Program) bool {
+	return p.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this Program, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid Program has no effect.
+func (p Program) SetLabel(label string) {
+	p.obj.setLabel(label)
+}
+
+// Label returns the debug label of this Program or an empty string if no
+// label has been set.
+func (p Program) Label() string {
+	return p.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this Program
+// (e.g. Program#12("name")).
+func (p Program) String() string {
+	return p.obj.format("Program")
 }
 
 // Result is a legacy alias for Any.
@@ -89,102 +233,724 @@ type Result = Any
 
 // Any represents an undefined return type. In the specification this is
 // indicated with the `any` keyword.
-type Any js.Value
+type Any struct {
+	value any
+}
+
+// NewAny returns an Any that wraps the specified backend-specific value.
+//
+// This function is meant to be used by Backend implementations.
+func NewAny(value any) Any {
+	return Any{value: value}
+}
+
+// Value returns the backend-specific value that is wrapped by this Any.
+func (r Any) Value() any {
+	return r.value
+}
 
 // IsValid returns whether this Any is specified and can be used.
 func (r Any) IsValid() bool {
-	return isSpecified(js.Value(r))
+	return isSpecified(r.value)
 }
 
 // GLboolean returns the contents of this Any as a GLboolean type.
 func (r Any) GLboolean() GLboolean {
-	return js.Value(r).Bool()
+	switch v := r.value.(type) {
+	case bool:
+		return v
+	case interface{ Bool() bool }:
+		return v.Bool()
+	default:
+		panic(fmt.Errorf("value of type %T is not a boolean", r.value))
+	}
 }
 
 // GLenum returns the contents of this Any as a GLenum type.
 func (r Any) GLenum() GLenum {
-	return GLenum(js.Value(r).Int())
+	return GLenum(r.int())
 }
 
 // GLint returns the contents of this Any as a GLint type.
 func (r Any) GLint() GLint {
-	return GLint(js.Value(r).Int())
+	return GLint(r.int())
 }
 
+func (r Any) int() int {
+	switch v := r.value.(type) {
+	case int:
+		return v
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	case uint32:
+		return int(v)
+	case uint64:
+		return int(v)
+	case float32:
+		return int(v)
+	case float64:
+		return int(v)
+	case interface{ Int() int }:
+		return v.Int()
+	default:
+		panic(fmt.Errorf("value of type %T is not a number", r.value))
+	}
+}
+
+// NilQuery equals the zero Query.
+var NilQuery = Query{}
+
+// Query represents the WebGLQuery type from the specification.
+type Query struct {
+	obj *object
+}
+
+// IsValid returns whether this Query is different from the zero Query or
+// an unspecified Query.
+func (q Query) IsValid() bool {
+	return q.obj != nil
+}
+
+// NewQuery returns a Query that wraps the specified backend-specific value.
+// The result equals the zero Query if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewQuery(value any) Query {
+	return Query{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this Query.
+func (q Query) Value() any {
+	return q.obj.get()
+}
+
+// ID returns a number that uniquely identifies this Query within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (q Query) ID() uint64 {
+	return q.obj.getID()
+}
+
+// Equal returns whether this Query is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (q Query) Equal(other Query) bool {
+	return q.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this Query, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid Query has no effect.
+func (q Query) SetLabel(label string) {
+	q.obj.setLabel(label)
+}
+
+// Label returns the debug label of this Query or an empty string if no
+// label has been set.
+func (q Query) Label() string {
+	return q.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this Query
+// (e.g. Query#12("name")).
+func (q Query) String() string {
+	return q.obj.format("Query")
+}
+
+// NilRenderbuffer equals the zero Renderbuffer.
+var NilRenderbuffer = Renderbuffer{}
+
+// Renderbuffer represents the WebGLRenderbuffer type from the specification.
+type Renderbuffer struct {
+	obj *object
+}
+
+// IsValid returns whether this Renderbuffer is different from the zero Renderbuffer or
+// an unspecified Renderbuffer.
+func (r Renderbuffer) IsValid() bool {
+	return r.obj != nil
+}
+
+// NewRenderbuffer returns a Renderbuffer that wraps the specified backend-specific value.
+// The result equals the zero Renderbuffer if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewRenderbuffer(value any) Renderbuffer {
+	return Renderbuffer{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this Renderbuffer.
+func (r Renderbuffer) Value() any {
+	return r.obj.get()
+}
+
+// ID returns a number that uniquely identifies this Renderbuffer within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (r Renderbuffer) ID() uint64 {
+	return r.obj.getID()
+}
+
+// Equal returns whether this Renderbuffer is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (r Renderbuffer) Equal(other Renderbuffer) bool {
+	return r.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this Renderbuffer, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid Renderbuffer has no effect.
+func (r Renderbuffer) SetLabel(label string) {
+	r.obj.setLabel(label)
+}
+
+// Label returns the debug label of this Renderbuffer or an empty string if no
+// label has been set.
+func (r Renderbuffer) Label() string {
+	return r.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this Renderbuffer
+// (e.g. Renderbuffer#12("name")).
+func (r Renderbuffer) String() string {
+	return r.obj.format("Renderbuffer")
+}
+
+// ColorSpace represents the PredefinedColorSpace type from the HTML
+// specification.
+type ColorSpace string
+
+const (
+	// ColorSpaceSRGB is the sRGB color space, which is the default.
+	ColorSpaceSRGB ColorSpace = "srgb"
+
+	// ColorSpaceDisplayP3 is the wide-gamut Display P3 color space.
+	ColorSpaceDisplayP3 ColorSpace = "display-p3"
+)
+
 // NilShader equals the zero Shader.
-var NilShader = Shader(js.Null())
+var NilShader = Shader{}
 
 // Shader represents the WebGLShader type from the specification.
-type Shader js.Value
+type Shader struct {
+	obj *object
+}
 
 // IsValid returns whether this Shader is different from the zero Shader or
 // an unspecified Shader.
 func (s Shader) IsValid() bool {
-	return isSpecified(js.Value(s))
+	return s.obj != nil
+}
+
+// NewShader returns a Shader that wraps the specified backend-specific value.
+// The result equals the zero Shader if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewShader(value any) Shader {
+	return Shader{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this Shader.
+func (s Shader) Value() any {
+	return s.obj.get()
+}
+
+// ID returns a number that uniquely identifies this Shader within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (s Shader) ID() uint64 {
+	return s.obj.getID()
+}
+
+// Equal returns whether this Shader is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (s Shader) Equal(other Shader) bool {
+	return s.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this Shader, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid Shader has no effect.
+func (s Shader) SetLabel(label string) {
+	s.obj.setLabel(label)
+}
+
+// Label returns the debug label of this Shader or an empty string if no
+// label has been set.
+func (s Shader) Label() string {
+	return s.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this Shader
+// (e.g. Shader#12("name")).
+func (s Shader) String() string {
+	return s.obj.format("Shader")
+}
+
+// ShaderPrecisionFormat describes the range and precision of a shader
+// numeric format, as returned by GetShaderPrecisionFormat.
+type ShaderPrecisionFormat struct {
+
+	// RangeMin is the base 2 log of the absolute value of the minimum value
+	// that can be represented.
+	RangeMin GLint
+
+	// RangeMax is the base 2 log of the absolute value of the maximum value
+	// that can be represented.
+	RangeMax GLint
+
+	// Precision is the number of bits of precision that can be represented.
+	// This is zero for integer formats.
+	Precision GLint
 }
 
 // NilSync equals the zero Sync.
-var NilSync = Sync(js.Null())
+var NilSync = Sync{}
 
 // Sync represents the WebGLSync type from the specification.
-type Sync js.Value
+type Sync struct {
+	obj *object
+}
 
 // IsValid returns whether this Sync is different from the zero Sync or an
 // unspecified Sync.
+func (s Sync) IsValid() bool {
+	return s.obj != nil
+}
+
+// Valid returns whether this Sync is different from the zero Sync or an
+// unspecified Sync.
+//
+// Deprecated: Use IsValid instead.
 func (s Sync) Valid() bool {
-	return isSpecified(js.Value(s))
+	return s.IsValid()
+}
+
+// NewSync returns a Sync that wraps the specified backend-specific value.
+// The result equals the zero Sync if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewSync(value any) Sync {
+	return Sync{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this Sync.
+func (s Sync) Value() any {
+	return s.obj.get()
+}
+
+// ID returns a number that uniquely identifies this Sync within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (s Sync) ID() uint64 {
+	return s.obj.getID()
+}
+
+// Equal returns whether this Sync is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (s Sync) Equal(other Sync) bool {
+	return s.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this Sync, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid Sync has no effect.
+func (s Sync) SetLabel(label string) {
+	s.obj.setLabel(label)
+}
+
+// Label returns the debug label of this Sync or an empty string if no
+// label has been set.
+func (s Sync) Label() string {
+	return s.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this Sync
+// (e.g. Sync#12("name")).
+func (s Sync) String() string {
+	return s.obj.format("Sync")
 }
 
 // NilTexture equals the zero Texture.
-var NilTexture = Texture(js.Null())
+var NilTexture = Texture{}
 
 // Texture represents the WebGLTexture type from the specification.
-type Texture js.Value
+type Texture struct {
+	obj *object
+}
 
 // IsValid returns whether this Texture is different from the zero Texture or
 // an unspecified Texture.
 func (t Texture) IsValid() bool {
-	return isSpecified(js.Value(t))
+	return t.obj != nil
+}
+
+// NewTexture returns a Texture that wraps the specified backend-specific value.
+// The result equals the zero Texture if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewTexture(value any) Texture {
+	return Texture{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this Texture.
+func (t Texture) Value() any {
+	return t.obj.get()
+}
+
+// ID returns a number that uniquely identifies this Texture within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (t Texture) ID() uint64 {
+	return t.obj.getID()
+}
+
+// Equal returns whether this Texture is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (t Texture) Equal(other Texture) bool {
+	return t.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this Texture, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid Texture has no effect.
+func (t Texture) SetLabel(label string) {
+	t.obj.setLabel(label)
+}
+
+// Label returns the debug label of this Texture or an empty string if no
+// label has been set.
+func (t Texture) Label() string {
+	return t.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this Texture
+// (e.g. Texture#12("name")).
+func (t Texture) String() string {
+	return t.obj.format("Texture")
 }
 
 // NilSampler equals the zero Sampler.
-var NilSampler = Sampler(js.Null())
+var NilSampler = Sampler{}
 
 // Sampler represents the WebGLSampler type from the specification.
-type Sampler js.Value
+type Sampler struct {
+	obj *object
+}
 
 // IsValid returns whether this Sampler is different from the zero Sampler or
 // an unspecified Sampler.
 func (s Sampler) IsValid() bool {
-	return isSpecified(js.Value(s))
+	return s.obj != nil
+}
+
+// NewSampler returns a Sampler that wraps the specified backend-specific value.
+// The result equals the zero Sampler if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewSampler(value any) Sampler {
+	return Sampler{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this Sampler.
+func (s Sampler) Value() any {
+	return s.obj.get()
+}
+
+// ID returns a number that uniquely identifies this Sampler within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (s Sampler) ID() uint64 {
+	return s.obj.getID()
+}
+
+// Equal returns whether this Sampler is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (s Sampler) Equal(other Sampler) bool {
+	return s.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this Sampler, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid Sampler has no effect.
+func (s Sampler) SetLabel(label string) {
+	s.obj.setLabel(label)
+}
+
+// Label returns the debug label of this Sampler or an empty string if no
+// label has been set.
+func (s Sampler) Label() string {
+	return s.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this Sampler
+// (e.g. Sampler#12("name")).
+func (s Sampler) String() string {
+	return s.obj.format("Sampler")
 }
 
 // NilUniformLocation equals the nil UniformLocation.
-var NilUniformLocation = UniformLocation(js.Null())
+var NilUniformLocation = UniformLocation{}
 
 // UniformLocation represents the WebGLUniformLocation type from the
 // specification.
-type UniformLocation js.Value
+type UniformLocation struct {
+	obj *object
+}
 
 // IsValid returns whether this UniformLocation is different from the nil
 // UniformLocation or an unspecified UniformLocation.
 func (l UniformLocation) IsValid() bool {
-	return isSpecified(js.Value(l))
+	return l.obj != nil
+}
+
+// NewUniformLocation returns a UniformLocation that wraps the specified backend-specific value.
+// The result equals the nil UniformLocation if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewUniformLocation(value any) UniformLocation {
+	return UniformLocation{obj: newDistinctObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this UniformLocation.
+func (l UniformLocation) Value() any {
+	return l.obj.get()
+}
+
+// ID returns a number that uniquely identifies this UniformLocation within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (l UniformLocation) ID() uint64 {
+	return l.obj.getID()
+}
+
+// Equal returns whether this UniformLocation is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (l UniformLocation) Equal(other UniformLocation) bool {
+	return l.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this UniformLocation, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid UniformLocation has no effect.
+func (l UniformLocation) SetLabel(label string) {
+	l.obj.setLabel(label)
+}
+
+// Label returns the debug label of this UniformLocation or an empty string if no
+// label has been set.
+func (l UniformLocation) Label() string {
+	return l.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this UniformLocation
+// (e.g. UniformLocation#12("name")).
+func (l UniformLocation) String() string {
+	return l.obj.format("UniformLocation")
 }
 
 // NilVertexArray equals the zero VertexArray.
-var NilVertexArray = VertexArray(js.Null())
+var NilVertexArray = VertexArray{}
 
 // VertexArray represents the WebGLVertexArrayObject type from the
 // specification.
-type VertexArray js.Value
+type VertexArray struct {
+	obj *object
+}
 
 // IsValid returns whether this VertexArray is different from the zero
 // VertexArray or an unspecified VertexArray.
 func (a VertexArray) IsValid() bool {
-	return isSpecified(js.Value(a))
+	return a.obj != nil
+}
+
+// NewVertexArray returns a VertexArray that wraps the specified backend-specific value.
+// The result equals the zero VertexArray if the value is not specified.
+//
+// This function is meant to be used by Backend implementations.
+func NewVertexArray(value any) VertexArray {
+	return VertexArray{obj: newObject(value)}
+}
+
+// Value returns the backend-specific value that is wrapped by this VertexArray.
+func (a VertexArray) Value() any {
+	return a.obj.get()
+}
+
+// ID returns a number that uniquely identifies this VertexArray within the
+// program. The ID is assigned when the object is first wrapped (e.g. by a
+// Create function) and is zero for invalid handles.
+func (a VertexArray) ID() uint64 {
+	return a.obj.getID()
+}
+
+// Equal returns whether this VertexArray is the same handle as the other
+// one. This is the same as comparing the handles with ==.
+func (a VertexArray) Equal(other VertexArray) bool {
+	return a.obj == other.obj
+}
+
+// SetLabel attaches a debug label to this VertexArray, which is used in
+// String and in debug reports. The label is shared by all copies of the
+// handle. Calling SetLabel on an invalid VertexArray has no effect.
+func (a VertexArray) SetLabel(label string) {
+	a.obj.setLabel(label)
+}
+
+// Label returns the debug label of this VertexArray or an empty string if no
+// label has been set.
+func (a VertexArray) Label() string {
+	return a.obj.getLabel()
+}
+
+// String returns the type, ID and label (if set) of this VertexArray
+// (e.g. VertexArray#12("name")).
+func (a VertexArray) String() string {
+	return a.obj.format("VertexArray")
+}
+
+// object holds the backend-specific value of a WebGL object. Handles
+// reference the object by pointer, which gives them their identity, so
+// handles can be compared with == and used as map keys.
+//
+// The label is stored atomically, since copies of a handle can be labeled
+// and formatted from different goroutines.
+type object struct {
+	id    uint64
+	value any
+	label atomic.Pointer[string]
+	key   any
+}
+
+var (
+	// lastObjectID is the ID that was assigned to the most recently created
+	// object.
+	lastObjectID atomic.Uint64
+
+	// registry maps the identity keys of backend-specific values to their
+	// objects, so that wrapping the same value again (e.g. when it is
+	// returned by GetParameter) results in the same handle.
+	//
+	// NOTE: Entries are removed when the object is deleted through one of
+	// the Delete functions. Objects that are never deleted stay registered.
+	registry   = make(map[any]*object)
+	registryMu sync.Mutex
+)
+
+// newObject returns the object for the specified backend-specific value,
+// which is the same object for values that refer to the same WebGL object.
+func newObject(value any) *object {
+	if !isSpecified(value) {
+		return nil
+	}
+	key, ok := identityKey(value)
+	if !ok {
+		return newDistinctObject(value)
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if obj, ok := registry[key]; ok {
+		return obj
+	}
+	obj := &object{
+		id:    lastObjectID.Add(1),
+		value: value,
+		key:   key,
+	}
+	registry[key] = obj
+	return obj
+}
+
+// newDistinctObject returns a new object for the specified backend-specific
+// value, without consulting the registry. This is used for values that
+// have no meaningful identity, like uniform locations, of which WebGL
+// returns new instances on each query.
+func newDistinctObject(value any) *object {
+	if !isSpecified(value) {
+		return nil
+	}
+	return &object{
+		id:    lastObjectID.Add(1),
+		value: value,
+	}
+}
+
+// release removes the object from the registry once the underlying WebGL
+// object has been deleted.
+func (o *object) release() {
+	if o == nil || o.key == nil {
+		return
+	}
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if registry[o.key] == o {
+		delete(registry, o.key)
+	}
+}
+
+// comparableKey returns the specified value as identity key if it can be
+// used as a map key.
+func comparableKey(value any) (any, bool) {
+	if !reflect.ValueOf(value).Comparable() {
+		return nil, false
+	}
+	return value, true
+}
+
+func (o *object) get() any {
+	if o == nil {
+		return nil
+	}
+	return o.value
+}
+
+func (o *object) getID() uint64 {
+	if o == nil {
+		return 0
+	}
+	return o.id
+}
+
+func (o *object) setLabel(label string) {
+	if o != nil {
+		o.label.Store(&label)
+	}
+}
+
+func (o *object) getLabel() string {
+	if o == nil {
+		return ""
+	}
+	if label := o.label.Load(); label != nil {
+		return *label
+	}
+	return ""
+}
+
+func (o *object) format(typeName string) string {
+	if o == nil {
+		return "Nil" + typeName
+	}
+	if label := o.getLabel(); label != "" {
+		return fmt.Sprintf("%s#%d(%q)", typeName, o.id, label)
+	}
+	return fmt.Sprintf("%s#%d", typeName, o.id)
+}
+
+// nullable is implemented by backend-specific values that have a notion
+// of null and undefined, like js.Value.
+type nullable interface {
+	IsNull() bool
+	IsUndefined() bool
 }
 
-func isSpecified(jsValue js.Value) bool {
-	return !jsValue.IsUndefined() && !jsValue.IsNull()
+func isSpecified(value any) bool {
+	if value == nil {
+		return false
+	}
+	if v, ok := value.(nullable); ok {
+		return !v.IsUndefined() && !v.IsNull()
+	}
+	return true
 }
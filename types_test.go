@@ -0,0 +1,193 @@
+package wasmgl_test
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+	"github.com/mokiat/wasmgl/wasmgltest"
+)
+
+// labeled is implemented by all object handles.
+type labeled interface {
+	fmt.Stringer
+	ID() uint64
+	Label() string
+}
+
+func TestLabels(t *testing.T) {
+	testCases := []struct {
+		name       string
+		handle     func(b *wasmgltest.Backend) labeled
+		wantLabel  string
+		wantString string
+	}{
+		{
+			name: "buffer",
+			handle: func(b *wasmgltest.Backend) labeled {
+				buffer := b.CreateBuffer()
+				buffer.SetLabel("vertices")
+				return buffer
+			},
+			wantLabel:  "vertices",
+			wantString: `Buffer#%d("vertices")`,
+		},
+		{
+			name: "copy of texture",
+			handle: func(b *wasmgltest.Backend) labeled {
+				texture := b.CreateTexture()
+				texture2 := texture
+				texture.SetLabel("albedo")
+				return texture2
+			},
+			wantLabel:  "albedo",
+			wantString: `Texture#%d("albedo")`,
+		},
+		{
+			name: "relabeled shader",
+			handle: func(b *wasmgltest.Backend) labeled {
+				shader := b.CreateShader(wasmgl.VERTEX_SHADER)
+				shader.SetLabel("first")
+				shader.SetLabel("second")
+				return shader
+			},
+			wantLabel:  "second",
+			wantString: `Shader#%d("second")`,
+		},
+		{
+			name: "program without label",
+			handle: func(b *wasmgltest.Backend) labeled {
+				return b.CreateProgram()
+			},
+			wantLabel:  "",
+			wantString: "Program#%d",
+		},
+		{
+			name: "invalid framebuffer",
+			handle: func(b *wasmgltest.Backend) labeled {
+				framebuffer := wasmgl.NilFramebuffer
+				framebuffer.SetLabel("ignored")
+				return framebuffer
+			},
+			wantLabel:  "",
+			wantString: "NilFramebuffer",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handle := tc.handle(wasmgltest.NewBackend())
+			if got := handle.Label(); got != tc.wantLabel {
+				t.Errorf("Label() = %q, want %q", got, tc.wantLabel)
+			}
+			want := tc.wantString
+			if id := handle.ID(); id != 0 {
+				want = fmt.Sprintf(tc.wantString, id)
+			}
+			if got := handle.String(); got != want {
+				t.Errorf("String() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestLabelsConcurrent(t *testing.T) {
+	buffer := wasmgltest.NewBackend().CreateBuffer()
+
+	var wg sync.WaitGroup
+	for i := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				buffer.SetLabel(fmt.Sprintf("buffer %d", i))
+				_ = buffer.String()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if buffer.Label() == "" {
+		t.Errorf("Label() is empty after concurrent SetLabel calls")
+	}
+}
+
+func TestIDs(t *testing.T) {
+	testCases := []struct {
+		name     string
+		handles  func(b *wasmgltest.Backend) (first, second labeled)
+		wantSame bool
+	}{
+		{
+			name: "different buffers",
+			handles: func(b *wasmgltest.Backend) (labeled, labeled) {
+				return b.CreateBuffer(), b.CreateBuffer()
+			},
+			wantSame: false,
+		},
+		{
+			name: "different object types",
+			handles: func(b *wasmgltest.Backend) (labeled, labeled) {
+				return b.CreateBuffer(), b.CreateTexture()
+			},
+			wantSame: false,
+		},
+		{
+			name: "rewrapped buffer",
+			handles: func(b *wasmgltest.Backend) (labeled, labeled) {
+				buffer := b.CreateBuffer()
+				return buffer, wasmgl.NewBuffer(buffer.Value())
+			},
+			wantSame: true,
+		},
+		{
+			name: "bound texture",
+			handles: func(b *wasmgltest.Backend) (labeled, labeled) {
+				texture := b.CreateTexture()
+				b.BindTexture(wasmgl.TEXTURE_2D, texture)
+				return texture, b.BoundTexture(wasmgl.TEXTURE0, wasmgl.TEXTURE_2D)
+			},
+			wantSame: true,
+		},
+		{
+			name: "attached shader",
+			handles: func(b *wasmgltest.Backend) (labeled, labeled) {
+				program := b.CreateProgram()
+				shader := b.CreateShader(wasmgl.VERTEX_SHADER)
+				b.AttachShader(program, shader)
+				return shader, b.GetAttachedShaders(program)[0]
+			},
+			wantSame: true,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			first, second := tc.handles(b)
+			b.ExpectNoError(t)
+
+			if first.ID() == 0 || second.ID() == 0 {
+				t.Fatalf("got IDs %d and %d, want non-zero", first.ID(), second.ID())
+			}
+			if same := first.ID() == second.ID(); same != tc.wantSame {
+				t.Errorf("got IDs %d and %d, want same %t", first.ID(), second.ID(), tc.wantSame)
+			}
+			if same := first == second; same != tc.wantSame {
+				t.Errorf("got %v == %v is %t, want %t", first, second, same, tc.wantSame)
+			}
+		})
+	}
+}
+
+func TestInvalidID(t *testing.T) {
+	handles := []labeled{
+		wasmgl.NilBuffer,
+		wasmgl.NilTexture,
+		wasmgl.NewProgram(nil),
+	}
+	for _, handle := range handles {
+		if id := handle.ID(); id != 0 {
+			t.Errorf("%v.ID() = %d, want 0", handle, id)
+		}
+	}
+}
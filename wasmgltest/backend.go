@@ -0,0 +1,532 @@
+// Package wasmgltest provides a pure-Go implementation of the wasmgl.Backend
+// interface that can be used to unit test rendering code with a regular
+// go test invocation.
+//
+// The Backend records every call together with its arguments, simulates the
+// creation and deletion of WebGL objects, keeps track of bound state and
+// follows the error flag semantics of GetError.
+package wasmgltest
+
+import (
+	"strconv"
+
+	"github.com/mokiat/wasmgl"
+)
+
+const (
+	noError = wasmgl.NO_ERROR
+
+	maxTextureUnits = 32
+
+	defaultDrawingBufferWidth  = 300
+	defaultDrawingBufferHeight = 150
+)
+
+var _ wasmgl.Backend = (*Backend)(nil)
+
+// NewBackend creates a new Backend with a default drawing buffer size of
+// 300x150 pixels, matching the default size of an HTML canvas.
+func NewBackend() *Backend {
+	b := &Backend{
+		drawingBufferWidth:      defaultDrawingBufferWidth,
+		drawingBufferHeight:     defaultDrawingBufferHeight,
+		drawingBufferFormat:     wasmgl.RGBA8,
+		drawingBufferColorSpace: wasmgl.ColorSpaceSRGB,
+		unpackColorSpace:        wasmgl.ColorSpaceSRGB,
+
+		err: noError,
+
+		activeTexture: wasmgl.TEXTURE0,
+		buffers:       make(map[wasmgl.GLenum]*object),
+		textures:      make(map[textureBinding]*object),
+		samplers:      make(map[wasmgl.GLuint]*object),
+		capabilities: map[wasmgl.GLenum]bool{
+			wasmgl.DITHER: true,
+		},
+		defaultVertexArray: newVertexArrayState(),
+
+		extensions: make(map[string]bool),
+		parameters: make(map[wasmgl.GLenum]any),
+		precisions: make(map[precisionKey]wasmgl.ShaderPrecisionFormat),
+	}
+	b.viewport = [4]wasmgl.GLint{0, 0, defaultDrawingBufferWidth, defaultDrawingBufferHeight}
+	b.scissor = b.viewport
+	return b
+}
+
+// Backend is a fake wasmgl.Backend implementation that is meant to be used
+// in unit tests.
+//
+// A Backend is not safe for concurrent use.
+type Backend struct {
+	calls []Call
+
+	drawingBufferWidth      int
+	drawingBufferHeight     int
+	drawingBufferFormat     wasmgl.GLenum
+	drawingBufferColorSpace wasmgl.ColorSpace
+	unpackColorSpace        wasmgl.ColorSpace
+
+	err wasmgl.GLenum
+
+	lastID  uint32
+	objects []*object
+
+	activeTexture      wasmgl.GLenum
+	buffers            map[wasmgl.GLenum]*object
+	textures           map[textureBinding]*object
+	samplers           map[wasmgl.GLuint]*object
+	program            *object
+	vertexArray        *object
+	defaultVertexArray *vertexArrayState
+	drawFramebuffer    *object
+	readFramebuffer    *object
+	renderbuffer       *object
+	capabilities       map[wasmgl.GLenum]bool
+	viewport           [4]wasmgl.GLint
+	scissor            [4]wasmgl.GLint
+
+	manualFences bool
+	contextLost  bool
+	outOfMemory  bool
+
+	extensions map[string]bool
+	parameters map[wasmgl.GLenum]any
+	precisions map[precisionKey]wasmgl.ShaderPrecisionFormat
+}
+
+// SetDrawingBufferSize changes the size of the simulated drawing buffer.
+func (b *Backend) SetDrawingBufferSize(width, height int) {
+	b.drawingBufferWidth = width
+	b.drawingBufferHeight = height
+}
+
+// EnableExtension marks the extension with the specified name as supported,
+// which makes GetExtension return a non-nil value for it.
+func (b *Backend) EnableExtension(name string) {
+	b.extensions[name] = true
+}
+
+// SetParameter configures the value that GetParameter returns for the
+// specified pname (e.g. MAX_TEXTURE_SIZE). Parameters that reflect bound
+// state (e.g. CURRENT_PROGRAM) are always derived from the simulated state.
+func (b *Backend) SetParameter(pname wasmgl.GLenum, value any) {
+	b.parameters[pname] = value
+}
+
+// SetShaderPrecisionFormat configures the value that GetShaderPrecisionFormat
+// returns for the specified shader type and precision type. By default, all
+// precision types report the ranges and precision of highp.
+func (b *Backend) SetShaderPrecisionFormat(shaderType, precisionType wasmgl.GLenum, format wasmgl.ShaderPrecisionFormat) {
+	b.precisions[precisionKey{shaderType: shaderType, precisionType: precisionType}] = format
+}
+
+// SetManualFences configures whether fences created through FenceSync stay
+// unsignaled until SignalFences is called. By default, fences are signaled
+// immediately.
+func (b *Backend) SetManualFences(manual bool) {
+	b.manualFences = manual
+}
+
+// SignalFences marks all existing fences as signaled, simulating the GPU
+// catching up with the submitted commands.
+func (b *Backend) SignalFences() {
+	for _, obj := range b.objects {
+		if obj.kind == syncKind {
+			obj.signaled = true
+		}
+	}
+}
+
+// LoseContext simulates the loss of the WebGL context. The next GetError
+// call returns CONTEXT_LOST_WEBGL, IsContextLost returns true and functions
+// that create objects return nil handles until RestoreContext is called.
+func (b *Backend) LoseContext() {
+	b.contextLost = true
+	b.err = wasmgl.CONTEXT_LOST_WEBGL
+}
+
+// RestoreContext ends the simulated context loss that was started through
+// LoseContext.
+func (b *Backend) RestoreContext() {
+	b.contextLost = false
+}
+
+// SetOutOfMemory configures whether storage allocations (BufferData,
+// TexStorage2D, TexStorage3D and RenderbufferStorage*) fail with an
+// OUT_OF_MEMORY error.
+func (b *Backend) SetOutOfMemory(outOfMemory bool) {
+	b.outOfMemory = outOfMemory
+}
+
+// ObjectID returns the ID that the Backend assigned to the specified object
+// handle when it was created. The zero value is returned for nil handles
+// or handles that were not created by this Backend.
+func (b *Backend) ObjectID(handle interface{ Value() any }) uint32 {
+	if obj, ok := handle.Value().(*object); ok {
+		return obj.id
+	}
+	return 0
+}
+
+// LiveObjects returns the number of objects that have been created and not
+// yet deleted. Uniform locations are not counted, since they cannot be
+// deleted.
+func (b *Backend) LiveObjects() int {
+	count := 0
+	for _, obj := range b.objects {
+		if !obj.deleted && obj.kind != uniformLocationKind {
+			count++
+		}
+	}
+	return count
+}
+
+// PeekError returns the current error flag without clearing it, unlike
+// GetError.
+func (b *Backend) PeekError() wasmgl.GLenum {
+	return b.err
+}
+
+// BoundBuffer returns the buffer that is bound to the specified target.
+func (b *Backend) BoundBuffer(target wasmgl.GLenum) wasmgl.Buffer {
+	if target == wasmgl.ELEMENT_ARRAY_BUFFER {
+		return wasmgl.NewBuffer(b.currentVertexArrayState().elementArrayBuffer.handle())
+	}
+	return wasmgl.NewBuffer(b.buffers[target].handle())
+}
+
+// BoundTexture returns the texture that is bound to the specified target
+// of the specified texture unit (e.g. TEXTURE0).
+func (b *Backend) BoundTexture(unit, target wasmgl.GLenum) wasmgl.Texture {
+	return wasmgl.NewTexture(b.textures[textureBinding{unit: unit, target: target}].handle())
+}
+
+// BoundSampler returns the sampler that is bound to the specified texture
+// unit index.
+func (b *Backend) BoundSampler(unit wasmgl.GLuint) wasmgl.Sampler {
+	return wasmgl.NewSampler(b.samplers[unit].handle())
+}
+
+// BoundRenderbuffer returns the renderbuffer that is currently bound.
+func (b *Backend) BoundRenderbuffer() wasmgl.Renderbuffer {
+	return wasmgl.NewRenderbuffer(b.renderbuffer.handle())
+}
+
+// CurrentProgram returns the program that is currently in use.
+func (b *Backend) CurrentProgram() wasmgl.Program {
+	return wasmgl.NewProgram(b.program.handle())
+}
+
+// BoundVertexArray returns the vertex array that is currently bound.
+func (b *Backend) BoundVertexArray() wasmgl.VertexArray {
+	return wasmgl.NewVertexArray(b.vertexArray.handle())
+}
+
+// BoundFramebuffer returns the framebuffer that is bound to the specified
+// target. FRAMEBUFFER is treated as DRAW_FRAMEBUFFER.
+func (b *Backend) BoundFramebuffer(target wasmgl.GLenum) wasmgl.Framebuffer {
+	if target == wasmgl.READ_FRAMEBUFFER {
+		return wasmgl.NewFramebuffer(b.readFramebuffer.handle())
+	}
+	return wasmgl.NewFramebuffer(b.drawFramebuffer.handle())
+}
+
+// IsVertexAttribArrayEnabled returns whether the vertex attribute at the
+// specified index is enabled on the currently bound vertex array.
+func (b *Backend) IsVertexAttribArrayEnabled(index wasmgl.GLuint) bool {
+	return b.currentVertexArrayState().enabledAttribs[index]
+}
+
+// CurrentViewport returns the currently configured viewport as x, y, width
+// and height.
+func (b *Backend) CurrentViewport() [4]wasmgl.GLint {
+	return b.viewport
+}
+
+// CurrentScissor returns the currently configured scissor box as x, y,
+// width and height.
+func (b *Backend) CurrentScissor() [4]wasmgl.GLint {
+	return b.scissor
+}
+
+func (b *Backend) record(name string, args ...any) {
+	for i, arg := range args {
+		args[i] = copyArg(arg)
+	}
+	b.calls = append(b.calls, Call{
+		Name: name,
+		Args: args,
+	})
+}
+
+// setError records the specified error code, unless there is already
+// a pending error, in which case it is discarded, like in WebGL.
+func (b *Backend) setError(code wasmgl.GLenum) {
+	if b.err == noError {
+		b.err = code
+	}
+}
+
+func (b *Backend) createObject(kind objectKind) *object {
+	b.lastID++
+	obj := &object{
+		id:   b.lastID,
+		kind: kind,
+	}
+	if kind == vertexArrayKind {
+		obj.vertexArray = newVertexArrayState()
+	}
+	b.objects = append(b.objects, obj)
+	return obj
+}
+
+// resolve returns the object that is wrapped by the specified handle.
+// A nil handle resolves to a nil object. If the handle does not belong to
+// this Backend, is of the wrong kind or has been deleted, then an
+// INVALID_OPERATION error is recorded and false is returned.
+func (b *Backend) resolve(handle interface{ Value() any }, kind objectKind) (*object, bool) {
+	value := handle.Value()
+	if value == nil {
+		return nil, true
+	}
+	obj, ok := value.(*object)
+	if !ok || obj.kind != kind || obj.deleted {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return nil, false
+	}
+	return obj, true
+}
+
+func (b *Backend) currentVertexArrayState() *vertexArrayState {
+	if b.vertexArray != nil {
+		return b.vertexArray.vertexArray
+	}
+	return b.defaultVertexArray
+}
+
+func (b *Backend) boundBuffer(target wasmgl.GLenum) (*object, bool) {
+	if !isBufferTarget(target) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return nil, false
+	}
+	var buffer *object
+	if target == wasmgl.ELEMENT_ARRAY_BUFFER {
+		buffer = b.currentVertexArrayState().elementArrayBuffer
+	} else {
+		buffer = b.buffers[target]
+	}
+	if buffer == nil {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return nil, false
+	}
+	return buffer, true
+}
+
+func (b *Backend) boundTexture(target wasmgl.GLenum) (*object, bool) {
+	if !isTextureTarget(target) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return nil, false
+	}
+	texture := b.textures[textureBinding{unit: b.activeTexture, target: target}]
+	if texture == nil {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return nil, false
+	}
+	return texture, true
+}
+
+// unbind removes all bindings to the specified object, which is what WebGL
+// does when a bound object is deleted.
+func (b *Backend) unbind(obj *object) {
+	for target, buffer := range b.buffers {
+		if buffer == obj {
+			delete(b.buffers, target)
+		}
+	}
+	if state := b.currentVertexArrayState(); state.elementArrayBuffer == obj {
+		state.elementArrayBuffer = nil
+	}
+	for binding, texture := range b.textures {
+		if texture == obj {
+			delete(b.textures, binding)
+		}
+	}
+	for unit, sampler := range b.samplers {
+		if sampler == obj {
+			delete(b.samplers, unit)
+		}
+	}
+	if b.vertexArray == obj {
+		b.vertexArray = nil
+	}
+	if b.drawFramebuffer == obj {
+		b.drawFramebuffer = nil
+	}
+	if b.readFramebuffer == obj {
+		b.readFramebuffer = nil
+	}
+	if b.renderbuffer == obj {
+		b.renderbuffer = nil
+	}
+}
+
+type objectKind string
+
+const (
+	bufferKind          objectKind = "buffer"
+	framebufferKind     objectKind = "framebuffer"
+	programKind         objectKind = "program"
+	queryKind           objectKind = "query"
+	renderbufferKind    objectKind = "renderbuffer"
+	samplerKind         objectKind = "sampler"
+	shaderKind          objectKind = "shader"
+	syncKind            objectKind = "sync"
+	textureKind         objectKind = "texture"
+	uniformLocationKind objectKind = "uniform location"
+	vertexArrayKind     objectKind = "vertex array"
+)
+
+// object represents a simulated WebGL object. A pointer to it is used as
+// the backend-specific value of wasmgl handles.
+type object struct {
+	id      uint32
+	kind    objectKind
+	deleted bool
+
+	// buffer state
+	data  []byte
+	usage wasmgl.GLenum
+
+	// shader state
+	shaderType wasmgl.GLenum
+	source     string
+	compiled   bool
+
+	// program state
+	shaders       []*object
+	linked        bool
+	validated     bool
+	attribs       map[string]wasmgl.GLint
+	uniformBlocks map[string]wasmgl.GLuint
+
+	// uniform location state
+	program *object
+	name    string
+
+	// texture state
+	target    wasmgl.GLenum
+	immutable bool
+
+	// sync state
+	signaled bool
+
+	// sampler state
+	parameters map[wasmgl.GLenum]any
+
+	// vertex array state
+	vertexArray *vertexArrayState
+}
+
+// handle returns the value that should be wrapped by wasmgl handles.
+func (o *object) handle() any {
+	if o == nil {
+		return nil
+	}
+	return o
+}
+
+// String returns a human-readable representation of the object that is
+// used when calls are printed.
+func (o *object) String() string {
+	return string(o.kind) + "#" + strconv.FormatUint(uint64(o.id), 10)
+}
+
+type vertexArrayState struct {
+	elementArrayBuffer *object
+	enabledAttribs     map[wasmgl.GLuint]bool
+}
+
+func newVertexArrayState() *vertexArrayState {
+	return &vertexArrayState{
+		enabledAttribs: make(map[wasmgl.GLuint]bool),
+	}
+}
+
+type textureBinding struct {
+	unit   wasmgl.GLenum
+	target wasmgl.GLenum
+}
+
+type precisionKey struct {
+	shaderType    wasmgl.GLenum
+	precisionType wasmgl.GLenum
+}
+
+func isBufferTarget(target wasmgl.GLenum) bool {
+	switch target {
+	case wasmgl.ARRAY_BUFFER, wasmgl.ELEMENT_ARRAY_BUFFER,
+		wasmgl.COPY_READ_BUFFER, wasmgl.COPY_WRITE_BUFFER,
+		wasmgl.TRANSFORM_FEEDBACK_BUFFER, wasmgl.UNIFORM_BUFFER,
+		wasmgl.PIXEL_PACK_BUFFER, wasmgl.PIXEL_UNPACK_BUFFER:
+		return true
+	default:
+		return false
+	}
+}
+
+func isTextureTarget(target wasmgl.GLenum) bool {
+	switch target {
+	case wasmgl.TEXTURE_2D, wasmgl.TEXTURE_CUBE_MAP, wasmgl.TEXTURE_3D, wasmgl.TEXTURE_2D_ARRAY:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFramebufferTarget(target wasmgl.GLenum) bool {
+	switch target {
+	case wasmgl.FRAMEBUFFER, wasmgl.DRAW_FRAMEBUFFER, wasmgl.READ_FRAMEBUFFER:
+		return true
+	default:
+		return false
+	}
+}
+
+func isCapability(cap wasmgl.GLenum) bool {
+	switch cap {
+	case wasmgl.BLEND, wasmgl.CULL_FACE, wasmgl.DEPTH_TEST, wasmgl.DITHER,
+		wasmgl.POLYGON_OFFSET_FILL, wasmgl.SAMPLE_ALPHA_TO_COVERAGE,
+		wasmgl.SAMPLE_COVERAGE, wasmgl.SCISSOR_TEST, wasmgl.STENCIL_TEST,
+		wasmgl.RASTERIZER_DISCARD:
+		return true
+	default:
+		return false
+	}
+}
+
+// isColorSpace returns whether the specified color space is one of the
+// values that browsers accept. Other values are ignored, like in browsers.
+func isColorSpace(colorSpace wasmgl.ColorSpace) bool {
+	switch colorSpace {
+	case wasmgl.ColorSpaceSRGB, wasmgl.ColorSpaceDisplayP3:
+		return true
+	default:
+		return false
+	}
+}
+
+func copyArg(arg any) any {
+	switch v := arg.(type) {
+	case []byte:
+		return append([]byte(nil), v...)
+	case []float32:
+		return append([]float32(nil), v...)
+	case []int32:
+		return append([]int32(nil), v...)
+	case []uint32:
+		return append([]uint32(nil), v...)
+	default:
+		return arg
+	}
+}
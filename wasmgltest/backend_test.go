@@ -0,0 +1,214 @@
+package wasmgltest_test
+
+import (
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+	"github.com/mokiat/wasmgl/wasmgltest"
+)
+
+func TestBackendErrors(t *testing.T) {
+	testCases := []struct {
+		name string
+		run  func(b *wasmgltest.Backend)
+		want wasmgl.GLenum
+	}{
+		{
+			name: "valid call",
+			run: func(b *wasmgltest.Backend) {
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, b.CreateBuffer())
+			},
+			want: wasmgl.NO_ERROR,
+		},
+		{
+			name: "invalid target",
+			run: func(b *wasmgltest.Backend) {
+				b.BindBuffer(wasmgl.TEXTURE_2D, b.CreateBuffer())
+			},
+			want: wasmgl.INVALID_ENUM,
+		},
+		{
+			name: "invalid capability",
+			run: func(b *wasmgltest.Backend) {
+				b.Enable(wasmgl.TEXTURE_2D)
+			},
+			want: wasmgl.INVALID_ENUM,
+		},
+		{
+			name: "deleted object",
+			run: func(b *wasmgltest.Backend) {
+				buffer := b.CreateBuffer()
+				b.DeleteBuffer(buffer)
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, buffer)
+			},
+			want: wasmgl.INVALID_OPERATION,
+		},
+		{
+			name: "object of a different kind",
+			run: func(b *wasmgltest.Backend) {
+				texture := b.CreateTexture()
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, wasmgl.NewBuffer(texture.Value()))
+			},
+			want: wasmgl.INVALID_OPERATION,
+		},
+		{
+			name: "texture bound to a different target",
+			run: func(b *wasmgltest.Backend) {
+				texture := b.CreateTexture()
+				b.BindTexture(wasmgl.TEXTURE_2D, texture)
+				b.BindTexture(wasmgl.TEXTURE_CUBE_MAP, texture)
+			},
+			want: wasmgl.INVALID_OPERATION,
+		},
+		{
+			name: "no bound buffer",
+			run: func(b *wasmgltest.Backend) {
+				b.BufferData(wasmgl.ARRAY_BUFFER, 16, nil, wasmgl.STATIC_DRAW)
+			},
+			want: wasmgl.INVALID_OPERATION,
+		},
+		{
+			name: "first error is kept",
+			run: func(b *wasmgltest.Backend) {
+				b.Enable(wasmgl.TEXTURE_2D)
+				b.BufferData(wasmgl.ARRAY_BUFFER, 16, nil, wasmgl.STATIC_DRAW)
+			},
+			want: wasmgl.INVALID_ENUM,
+		},
+		{
+			name: "out of memory",
+			run: func(b *wasmgltest.Backend) {
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, b.CreateBuffer())
+				b.SetOutOfMemory(true)
+				b.BufferData(wasmgl.ARRAY_BUFFER, 16, nil, wasmgl.STATIC_DRAW)
+			},
+			want: wasmgl.OUT_OF_MEMORY,
+		},
+		{
+			name: "context lost",
+			run: func(b *wasmgltest.Backend) {
+				b.LoseContext()
+			},
+			want: wasmgl.CONTEXT_LOST_WEBGL,
+		},
+		{
+			name: "cube map face image target",
+			run: func(b *wasmgltest.Backend) {
+				b.BindTexture(wasmgl.TEXTURE_CUBE_MAP, b.CreateTexture())
+				b.TexImage2D(wasmgl.TEXTURE_CUBE_MAP_POSITIVE_X, 0, wasmgl.RGBA8, 4, 4, 0, wasmgl.RGBA, wasmgl.UNSIGNED_BYTE, nil)
+				b.CopyTexSubImage2D(wasmgl.TEXTURE_CUBE_MAP_POSITIVE_X, 0, 0, 0, 0, 0, 4, 4)
+			},
+			want: wasmgl.NO_ERROR,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			tc.run(b)
+			if got := b.GetError(); got != tc.want {
+				t.Errorf("GetError() = %s, want %s", wasmgl.EnumName(got, wasmgl.EnumCategoryError), wasmgl.EnumName(tc.want, wasmgl.EnumCategoryError))
+			}
+			if got := b.GetError(); got != wasmgl.NO_ERROR {
+				t.Errorf("second GetError() = %s, want NO_ERROR", wasmgl.EnumName(got, wasmgl.EnumCategoryError))
+			}
+		})
+	}
+}
+
+func TestBackendBindings(t *testing.T) {
+	testCases := []struct {
+		name string
+		run  func(b *wasmgltest.Backend) (got, want any)
+	}{
+		{
+			name: "bound buffer",
+			run: func(b *wasmgltest.Backend) (any, any) {
+				buffer := b.CreateBuffer()
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, buffer)
+				return b.BoundBuffer(wasmgl.ARRAY_BUFFER).Value(), buffer.Value()
+			},
+		},
+		{
+			name: "element buffer of vertex array",
+			run: func(b *wasmgltest.Backend) (any, any) {
+				buffer := b.CreateBuffer()
+				b.BindVertexArray(b.CreateVertexArray())
+				b.BindBuffer(wasmgl.ELEMENT_ARRAY_BUFFER, buffer)
+				b.BindVertexArray(wasmgl.NilVertexArray)
+				return b.BoundBuffer(wasmgl.ELEMENT_ARRAY_BUFFER).Value(), wasmgl.NilBuffer.Value()
+			},
+		},
+		{
+			name: "deleted buffer is unbound",
+			run: func(b *wasmgltest.Backend) (any, any) {
+				buffer := b.CreateBuffer()
+				b.BindBuffer(wasmgl.ARRAY_BUFFER, buffer)
+				b.DeleteBuffer(buffer)
+				return b.BoundBuffer(wasmgl.ARRAY_BUFFER).Value(), wasmgl.NilBuffer.Value()
+			},
+		},
+		{
+			name: "texture of active unit",
+			run: func(b *wasmgltest.Backend) (any, any) {
+				texture := b.CreateTexture()
+				b.ActiveTexture(wasmgl.TEXTURE3)
+				b.BindTexture(wasmgl.TEXTURE_2D, texture)
+				return b.BoundTexture(wasmgl.TEXTURE3, wasmgl.TEXTURE_2D).Value(), texture.Value()
+			},
+		},
+		{
+			name: "texture of other unit",
+			run: func(b *wasmgltest.Backend) (any, any) {
+				b.ActiveTexture(wasmgl.TEXTURE3)
+				b.BindTexture(wasmgl.TEXTURE_2D, b.CreateTexture())
+				return b.BoundTexture(wasmgl.TEXTURE0, wasmgl.TEXTURE_2D).Value(), wasmgl.NilTexture.Value()
+			},
+		},
+		{
+			name: "framebuffer binds both targets",
+			run: func(b *wasmgltest.Backend) (any, any) {
+				framebuffer := b.CreateFramebuffer()
+				b.BindFramebuffer(wasmgl.FRAMEBUFFER, framebuffer)
+				return b.BoundFramebuffer(wasmgl.READ_FRAMEBUFFER).Value(), framebuffer.Value()
+			},
+		},
+		{
+			name: "current program",
+			run: func(b *wasmgltest.Backend) (any, any) {
+				program := linkedProgram(b)
+				b.UseProgram(program)
+				return b.CurrentProgram().Value(), program.Value()
+			},
+		},
+		{
+			name: "uniform locations are not live objects",
+			run: func(b *wasmgltest.Backend) (any, any) {
+				program := linkedProgram(b)
+				b.GetUniformLocation(program, "color")
+				b.GetUniformLocation(program, "color")
+				return b.LiveObjects(), 3
+			},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := wasmgltest.NewBackend()
+			got, want := tc.run(b)
+			b.ExpectNoError(t)
+			if got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func linkedProgram(b *wasmgltest.Backend) wasmgl.Program {
+	program := b.CreateProgram()
+	for _, shaderType := range []wasmgl.GLenum{wasmgl.VERTEX_SHADER, wasmgl.FRAGMENT_SHADER} {
+		shader := b.CreateShader(shaderType)
+		b.CompileShader(shader)
+		b.AttachShader(program, shader)
+	}
+	b.LinkProgram(program)
+	return program
+}
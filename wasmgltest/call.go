@@ -0,0 +1,177 @@
+package wasmgltest
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mokiat/wasmgl"
+)
+
+// Call represents a single call that was made to a Backend.
+type Call struct {
+
+	// Name is the name of the called function (e.g. "BindFramebuffer").
+	Name string
+
+	// Args holds the arguments that were passed to the function. Slices are
+	// copied at the time of the call.
+	Args []any
+}
+
+// String returns a human-readable representation of this Call.
+func (c Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		if handle, ok := arg.(interface{ Value() any }); ok {
+			arg = handle.Value()
+		}
+		args[i] = fmt.Sprintf("%v", arg)
+	}
+	return fmt.Sprintf("%s(%s)", c.Name, strings.Join(args, ", "))
+}
+
+// Matcher checks whether a Call satisfies certain criteria.
+type Matcher func(call Call) bool
+
+// AnyArg can be used as an argument to CallTo to indicate that any value is
+// acceptable at that position.
+var AnyArg = anyArg{}
+
+type anyArg struct{}
+
+// CallTo returns a Matcher that matches calls to the function with the
+// specified name. If any args are specified, then the call needs to have
+// exactly that many arguments and each one needs to be equal to the
+// corresponding expected one, unless AnyArg is used.
+//
+// Object handles (e.g. wasmgl.Buffer) are compared by identity and untyped
+// numeric constants are compared by value, so CallTo("Enable",
+// wasmgl.DEPTH_TEST) works as expected.
+func CallTo(name string, args ...any) Matcher {
+	return func(call Call) bool {
+		if call.Name != name {
+			return false
+		}
+		if len(args) == 0 {
+			return true
+		}
+		if len(args) != len(call.Args) {
+			return false
+		}
+		for i, arg := range args {
+			if !argEqual(arg, call.Args[i]) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// Calls returns a copy of all calls that have been recorded so far.
+func (b *Backend) Calls() []Call {
+	result := make([]Call, len(b.calls))
+	copy(result, b.calls)
+	return result
+}
+
+// CallsTo returns all recorded calls to the function with the specified
+// name.
+func (b *Backend) CallsTo(name string) []Call {
+	var result []Call
+	for _, call := range b.calls {
+		if call.Name == name {
+			result = append(result, call)
+		}
+	}
+	return result
+}
+
+// ResetCalls clears all recorded calls. The simulated state is retained.
+func (b *Backend) ResetCalls() {
+	b.calls = b.calls[:0]
+}
+
+// HasSequence returns whether the recorded calls contain calls that satisfy
+// the specified matchers in the specified order. Other calls are allowed to
+// appear in between.
+func (b *Backend) HasSequence(matchers ...Matcher) bool {
+	return b.matchSequence(matchers) == len(matchers)
+}
+
+// ExpectSequence reports a test error if the recorded calls do not contain
+// calls that satisfy the specified matchers in the specified order.
+func (b *Backend) ExpectSequence(t testing.TB, matchers ...Matcher) {
+	t.Helper()
+	if matched := b.matchSequence(matchers); matched != len(matchers) {
+		t.Errorf("expected call sequence not found: matcher #%d had no match after previous ones; recorded calls:\n%s", matched, b.formatCalls())
+	}
+}
+
+// ExpectNoError reports a test error if the simulated context has a pending
+// error. The error flag is not cleared.
+func (b *Backend) ExpectNoError(t testing.TB) {
+	t.Helper()
+	if b.err != noError {
+		t.Errorf("expected no GL error but got %s; recorded calls:\n%s", wasmgl.EnumName(b.err, wasmgl.EnumCategoryError), b.formatCalls())
+	}
+}
+
+func (b *Backend) matchSequence(matchers []Matcher) int {
+	matched := 0
+	for _, call := range b.calls {
+		if matched == len(matchers) {
+			break
+		}
+		if matchers[matched](call) {
+			matched++
+		}
+	}
+	return matched
+}
+
+func (b *Backend) formatCalls() string {
+	var builder strings.Builder
+	for i, call := range b.calls {
+		fmt.Fprintf(&builder, "\t%d: %s\n", i, call)
+	}
+	return builder.String()
+}
+
+func argEqual(expected, actual any) bool {
+	if _, ok := expected.(anyArg); ok {
+		return true
+	}
+	if e, ok := expected.(interface{ Value() any }); ok {
+		a, ok := actual.(interface{ Value() any })
+		return ok && e.Value() == a.Value()
+	}
+	ev, av := reflect.ValueOf(expected), reflect.ValueOf(actual)
+	if isNumeric(ev) && isNumeric(av) {
+		return numericValue(ev) == numericValue(av)
+	}
+	return reflect.DeepEqual(expected, actual)
+}
+
+func isNumeric(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func numericValue(v reflect.Value) float64 {
+	switch {
+	case v.CanInt():
+		return float64(v.Int())
+	case v.CanUint():
+		return float64(v.Uint())
+	default:
+		return v.Float()
+	}
+}
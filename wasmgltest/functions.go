@@ -0,0 +1,1596 @@
+package wasmgltest
+
+import "github.com/mokiat/wasmgl"
+
+func (b *Backend) ActiveTexture(texture wasmgl.GLenum) {
+	b.record("ActiveTexture", texture)
+	if texture < wasmgl.TEXTURE0 || texture >= wasmgl.TEXTURE0+maxTextureUnits {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	b.activeTexture = texture
+}
+
+func (b *Backend) AttachShader(program wasmgl.Program, shader wasmgl.Shader) {
+	b.record("AttachShader", program, shader)
+	programObj, ok := b.resolve(program, programKind)
+	if !ok {
+		return
+	}
+	shaderObj, ok := b.resolve(shader, shaderKind)
+	if !ok {
+		return
+	}
+	if programObj == nil || shaderObj == nil {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	for _, attached := range programObj.shaders {
+		if attached == shaderObj || attached.shaderType == shaderObj.shaderType {
+			b.setError(wasmgl.INVALID_OPERATION)
+			return
+		}
+	}
+	programObj.shaders = append(programObj.shaders, shaderObj)
+}
+
+func (b *Backend) BindBuffer(target wasmgl.GLenum, buffer wasmgl.Buffer) {
+	b.record("BindBuffer", target, buffer)
+	b.bindBuffer(target, buffer)
+}
+
+func (b *Backend) BindBufferBase(target wasmgl.GLenum, index wasmgl.GLuint, buffer wasmgl.Buffer) {
+	b.record("BindBufferBase", target, index, buffer)
+	if target != wasmgl.UNIFORM_BUFFER && target != wasmgl.TRANSFORM_FEEDBACK_BUFFER {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	b.bindBuffer(target, buffer)
+}
+
+func (b *Backend) BindBufferRange(target wasmgl.GLenum, index wasmgl.GLuint, buffer wasmgl.Buffer, offset wasmgl.GLintptr, size wasmgl.GLsizeiptr) {
+	b.record("BindBufferRange", target, index, buffer, offset, size)
+	if target != wasmgl.UNIFORM_BUFFER && target != wasmgl.TRANSFORM_FEEDBACK_BUFFER {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	if offset < 0 || size <= 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	b.bindBuffer(target, buffer)
+}
+
+func (b *Backend) bindBuffer(target wasmgl.GLenum, buffer wasmgl.Buffer) {
+	if !isBufferTarget(target) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	obj, ok := b.resolve(buffer, bufferKind)
+	if !ok {
+		return
+	}
+	if target == wasmgl.ELEMENT_ARRAY_BUFFER {
+		b.currentVertexArrayState().elementArrayBuffer = obj
+		return
+	}
+	if obj == nil {
+		delete(b.buffers, target)
+	} else {
+		b.buffers[target] = obj
+	}
+}
+
+func (b *Backend) BindFramebuffer(target wasmgl.GLenum, framebuffer wasmgl.Framebuffer) {
+	b.record("BindFramebuffer", target, framebuffer)
+	if !isFramebufferTarget(target) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	obj, ok := b.resolve(framebuffer, framebufferKind)
+	if !ok {
+		return
+	}
+	switch target {
+	case wasmgl.FRAMEBUFFER:
+		b.drawFramebuffer = obj
+		b.readFramebuffer = obj
+	case wasmgl.DRAW_FRAMEBUFFER:
+		b.drawFramebuffer = obj
+	case wasmgl.READ_FRAMEBUFFER:
+		b.readFramebuffer = obj
+	}
+}
+
+func (b *Backend) BindRenderbuffer(target wasmgl.GLenum, renderbuffer wasmgl.Renderbuffer) {
+	b.record("BindRenderbuffer", target, renderbuffer)
+	if target != wasmgl.RENDERBUFFER {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	obj, ok := b.resolve(renderbuffer, renderbufferKind)
+	if !ok {
+		return
+	}
+	b.renderbuffer = obj
+}
+
+func (b *Backend) BindSampler(unit wasmgl.GLuint, sampler wasmgl.Sampler) {
+	b.record("BindSampler", unit, sampler)
+	if unit >= maxTextureUnits {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	obj, ok := b.resolve(sampler, samplerKind)
+	if !ok {
+		return
+	}
+	if obj == nil {
+		delete(b.samplers, unit)
+	} else {
+		b.samplers[unit] = obj
+	}
+}
+
+func (b *Backend) BindTexture(target wasmgl.GLenum, texture wasmgl.Texture) {
+	b.record("BindTexture", target, texture)
+	if !isTextureTarget(target) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	obj, ok := b.resolve(texture, textureKind)
+	if !ok {
+		return
+	}
+	binding := textureBinding{unit: b.activeTexture, target: target}
+	if obj == nil {
+		delete(b.textures, binding)
+		return
+	}
+	if obj.target != 0 && obj.target != target {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return
+	}
+	obj.target = target
+	b.textures[binding] = obj
+}
+
+func (b *Backend) BindVertexArray(array wasmgl.VertexArray) {
+	b.record("BindVertexArray", array)
+	obj, ok := b.resolve(array, vertexArrayKind)
+	if !ok {
+		return
+	}
+	b.vertexArray = obj
+}
+
+func (b *Backend) BlendColor(red, green, blue, alpha wasmgl.GLclampf) {
+	b.record("BlendColor", red, green, blue, alpha)
+}
+
+func (b *Backend) BlendEquation(mode wasmgl.GLenum) {
+	b.record("BlendEquation", mode)
+}
+
+func (b *Backend) BlendEquationSeparate(modeRGB, modeAlpha wasmgl.GLenum) {
+	b.record("BlendEquationSeparate", modeRGB, modeAlpha)
+}
+
+func (b *Backend) BlendFunc(sfactor, dfactor wasmgl.GLenum) {
+	b.record("BlendFunc", sfactor, dfactor)
+}
+
+func (b *Backend) BlendFuncSeparate(srcRGB, dstRGB, srcAlpha, dstAlpha wasmgl.GLenum) {
+	b.record("BlendFuncSeparate", srcRGB, dstRGB, srcAlpha, dstAlpha)
+}
+
+func (b *Backend) BlitFramebuffer(srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1 wasmgl.GLint, mask wasmgl.GLbitfield, filter wasmgl.GLenum) {
+	b.record("BlitFramebuffer", srcX0, srcY0, srcX1, srcY1, dstX0, dstY0, dstX1, dstY1, mask, filter)
+	if b.drawFramebuffer == b.readFramebuffer {
+		b.setError(wasmgl.INVALID_OPERATION)
+	}
+}
+
+func (b *Backend) BufferData(target wasmgl.GLenum, size wasmgl.GLsizeiptr, data []byte, usage wasmgl.GLenum) {
+	b.record("BufferData", target, size, data, usage)
+	buffer, ok := b.boundBuffer(target)
+	if !ok {
+		return
+	}
+	if b.outOfMemory {
+		b.setError(wasmgl.OUT_OF_MEMORY)
+		return
+	}
+	if data != nil {
+		buffer.data = append([]byte(nil), data...)
+	} else {
+		if size < 0 {
+			b.setError(wasmgl.INVALID_VALUE)
+			return
+		}
+		buffer.data = make([]byte, size)
+	}
+	buffer.usage = usage
+}
+
+func (b *Backend) BufferSubData(target wasmgl.GLenum, dstOffset wasmgl.GLintptr, data []byte) {
+	b.record("BufferSubData", target, dstOffset, data)
+	buffer, ok := b.boundBuffer(target)
+	if !ok {
+		return
+	}
+	if dstOffset < 0 || int(dstOffset)+len(data) > len(buffer.data) {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	copy(buffer.data[dstOffset:], data)
+}
+
+func (b *Backend) CheckFramebufferStatus(target wasmgl.GLenum) wasmgl.GLenum {
+	b.record("CheckFramebufferStatus", target)
+	if !isFramebufferTarget(target) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return 0
+	}
+	return wasmgl.FRAMEBUFFER_COMPLETE
+}
+
+func (b *Backend) Clear(mask wasmgl.GLbitfield) {
+	b.record("Clear", mask)
+	if mask&^(wasmgl.COLOR_BUFFER_BIT|wasmgl.DEPTH_BUFFER_BIT|wasmgl.STENCIL_BUFFER_BIT) != 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+func (b *Backend) ClearBufferfv(buffer wasmgl.GLenum, drawBuffer wasmgl.GLint, values wasmgl.Float32List) {
+	b.record("ClearBufferfv", buffer, drawBuffer, values)
+	b.checkClearValues(buffer, len(values), 0)
+}
+
+func (b *Backend) ClearBufferfvOffset(buffer wasmgl.GLenum, drawBuffer wasmgl.GLint, values wasmgl.Float32List, srcOffset wasmgl.GLuint) {
+	b.record("ClearBufferfvOffset", buffer, drawBuffer, values, srcOffset)
+	b.checkClearValues(buffer, len(values), srcOffset)
+}
+
+func (b *Backend) ClearBufferiv(buffer wasmgl.GLenum, drawBuffer wasmgl.GLint, values wasmgl.Int32List) {
+	b.record("ClearBufferiv", buffer, drawBuffer, values)
+	b.checkClearValues(buffer, len(values), 0)
+}
+
+func (b *Backend) ClearBufferivOffset(buffer wasmgl.GLenum, drawBuffer wasmgl.GLint, values wasmgl.Int32List, srcOffset wasmgl.GLuint) {
+	b.record("ClearBufferivOffset", buffer, drawBuffer, values, srcOffset)
+	b.checkClearValues(buffer, len(values), srcOffset)
+}
+
+func (b *Backend) ClearBufferuiv(buffer wasmgl.GLenum, drawBuffer wasmgl.GLint, values wasmgl.Uint32List) {
+	b.record("ClearBufferuiv", buffer, drawBuffer, values)
+	b.checkClearValues(buffer, len(values), 0)
+}
+
+func (b *Backend) ClearBufferuivOffset(buffer wasmgl.GLenum, drawBuffer wasmgl.GLint, values wasmgl.Uint32List, srcOffset wasmgl.GLuint) {
+	b.record("ClearBufferuivOffset", buffer, drawBuffer, values, srcOffset)
+	b.checkClearValues(buffer, len(values), srcOffset)
+}
+
+// checkClearValues verifies that enough values are available at the
+// specified offset for the specified buffer.
+func (b *Backend) checkClearValues(buffer wasmgl.GLenum, count int, srcOffset wasmgl.GLuint) {
+	var need int
+	switch buffer {
+	case wasmgl.COLOR:
+		need = 4
+	case wasmgl.DEPTH, wasmgl.STENCIL:
+		need = 1
+	default:
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	if int(srcOffset)+need > count {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+func (b *Backend) ClearBufferfi(buffer wasmgl.GLenum, drawBuffer wasmgl.GLint, depth wasmgl.GLfloat, stencil wasmgl.GLint) {
+	b.record("ClearBufferfi", buffer, drawBuffer, depth, stencil)
+}
+
+func (b *Backend) ClearColor(r, g, bl, a wasmgl.GLclampf) {
+	b.record("ClearColor", r, g, bl, a)
+}
+
+func (b *Backend) ClearDepth(depth wasmgl.GLclampf) {
+	b.record("ClearDepth", depth)
+}
+
+func (b *Backend) ClearStencil(stencil wasmgl.GLint) {
+	b.record("ClearStencil", stencil)
+}
+
+func (b *Backend) ClientWaitSync(sync wasmgl.Sync, flags wasmgl.GLbitfield, timeout wasmgl.GLuint64) wasmgl.GLenum {
+	b.record("ClientWaitSync", sync, flags, timeout)
+	obj, ok := b.resolve(sync, syncKind)
+	if !ok || obj == nil {
+		b.setError(wasmgl.INVALID_VALUE)
+		return wasmgl.WAIT_FAILED
+	}
+	if !obj.signaled {
+		return wasmgl.TIMEOUT_EXPIRED
+	}
+	return wasmgl.ALREADY_SIGNALED
+}
+
+func (b *Backend) ColorMask(r, g, bl, a wasmgl.GLboolean) {
+	b.record("ColorMask", r, g, bl, a)
+}
+
+func (b *Backend) CompileShader(shader wasmgl.Shader) {
+	b.record("CompileShader", shader)
+	obj, ok := b.resolve(shader, shaderKind)
+	if !ok || obj == nil {
+		return
+	}
+	obj.compiled = true
+}
+
+func (b *Backend) CompressedTexImage2D(target wasmgl.GLenum, level wasmgl.GLint, internalFormat wasmgl.GLenum, width, height wasmgl.GLsizei, border wasmgl.GLint, data []byte) {
+	b.record("CompressedTexImage2D", target, level, internalFormat, width, height, border, data)
+	if b.checkUnpackSource(false, 0) {
+		b.texImage(target, width, height, 1, border)
+	}
+}
+
+func (b *Backend) CompressedTexImage2DOffset(target wasmgl.GLenum, level wasmgl.GLint, internalFormat wasmgl.GLenum, width, height wasmgl.GLsizei, border wasmgl.GLint, imageSize wasmgl.GLsizei, offset wasmgl.GLintptr) {
+	b.record("CompressedTexImage2DOffset", target, level, internalFormat, width, height, border, imageSize, offset)
+	if b.checkUnpackSource(true, offset) {
+		b.texImage(target, width, height, 1, border)
+	}
+}
+
+func (b *Backend) CompressedTexImage3D(target wasmgl.GLenum, level wasmgl.GLint, internalFormat wasmgl.GLenum, width, height, depth wasmgl.GLsizei, border wasmgl.GLint, data []byte) {
+	b.record("CompressedTexImage3D", target, level, internalFormat, width, height, depth, border, data)
+	if b.checkUnpackSource(false, 0) {
+		b.texImage(target, width, height, depth, border)
+	}
+}
+
+func (b *Backend) CompressedTexImage3DOffset(target wasmgl.GLenum, level wasmgl.GLint, internalFormat wasmgl.GLenum, width, height, depth wasmgl.GLsizei, border wasmgl.GLint, imageSize wasmgl.GLsizei, offset wasmgl.GLintptr) {
+	b.record("CompressedTexImage3DOffset", target, level, internalFormat, width, height, depth, border, imageSize, offset)
+	if b.checkUnpackSource(true, offset) {
+		b.texImage(target, width, height, depth, border)
+	}
+}
+
+func (b *Backend) CompressedTexSubImage2D(target wasmgl.GLenum, level, xoffset, yoffset wasmgl.GLint, width, height wasmgl.GLsizei, format wasmgl.GLenum, data []byte) {
+	b.record("CompressedTexSubImage2D", target, level, xoffset, yoffset, width, height, format, data)
+	if b.checkUnpackSource(false, 0) {
+		b.boundTexture(textureBindingTarget(target))
+	}
+}
+
+func (b *Backend) CompressedTexSubImage2DOffset(target wasmgl.GLenum, level, xoffset, yoffset wasmgl.GLint, width, height wasmgl.GLsizei, format wasmgl.GLenum, imageSize wasmgl.GLsizei, offset wasmgl.GLintptr) {
+	b.record("CompressedTexSubImage2DOffset", target, level, xoffset, yoffset, width, height, format, imageSize, offset)
+	if b.checkUnpackSource(true, offset) {
+		b.boundTexture(textureBindingTarget(target))
+	}
+}
+
+func (b *Backend) CompressedTexSubImage3D(target wasmgl.GLenum, level, xoffset, yoffset, zoffset wasmgl.GLint, width, height, depth wasmgl.GLsizei, format wasmgl.GLenum, data []byte) {
+	b.record("CompressedTexSubImage3D", target, level, xoffset, yoffset, zoffset, width, height, depth, format, data)
+	if b.checkUnpackSource(false, 0) {
+		b.boundTexture(textureBindingTarget(target))
+	}
+}
+
+func (b *Backend) CompressedTexSubImage3DOffset(target wasmgl.GLenum, level, xoffset, yoffset, zoffset wasmgl.GLint, width, height, depth wasmgl.GLsizei, format wasmgl.GLenum, imageSize wasmgl.GLsizei, offset wasmgl.GLintptr) {
+	b.record("CompressedTexSubImage3DOffset", target, level, xoffset, yoffset, zoffset, width, height, depth, format, imageSize, offset)
+	if b.checkUnpackSource(true, offset) {
+		b.boundTexture(textureBindingTarget(target))
+	}
+}
+
+func (b *Backend) CopyBufferSubData(readTarget, writeTarget wasmgl.GLenum, readOffset, writeOffset wasmgl.GLintptr, size wasmgl.GLsizeiptr) {
+	b.record("CopyBufferSubData", readTarget, writeTarget, readOffset, writeOffset, size)
+	readBuffer, ok := b.boundBuffer(readTarget)
+	if !ok {
+		return
+	}
+	writeBuffer, ok := b.boundBuffer(writeTarget)
+	if !ok {
+		return
+	}
+	if readOffset < 0 || writeOffset < 0 || size < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	if int(readOffset)+int(size) > len(readBuffer.data) || int(writeOffset)+int(size) > len(writeBuffer.data) {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	if readBuffer == writeBuffer && readOffset < writeOffset+wasmgl.GLintptr(size) && writeOffset < readOffset+wasmgl.GLintptr(size) {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	copy(writeBuffer.data[writeOffset:], readBuffer.data[readOffset:int(readOffset)+int(size)])
+}
+
+func (b *Backend) CopyTexImage2D(target wasmgl.GLenum, level wasmgl.GLint, internalFormat wasmgl.GLenum, x, y wasmgl.GLint, width, height wasmgl.GLsizei, border wasmgl.GLint) {
+	b.record("CopyTexImage2D", target, level, internalFormat, x, y, width, height, border)
+	texture, ok := b.boundTexture(textureBindingTarget(target))
+	if !ok {
+		return
+	}
+	if texture.immutable {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return
+	}
+	if width < 0 || height < 0 || border != 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+func (b *Backend) CopyTexSubImage2D(target wasmgl.GLenum, level, xoffset, yoffset, x, y wasmgl.GLint, width, height wasmgl.GLsizei) {
+	b.record("CopyTexSubImage2D", target, level, xoffset, yoffset, x, y, width, height)
+	b.boundTexture(textureBindingTarget(target))
+}
+
+func (b *Backend) CopyTexSubImage3D(target wasmgl.GLenum, level, xoffset, yoffset, zoffset, x, y wasmgl.GLint, width, height wasmgl.GLsizei) {
+	b.record("CopyTexSubImage3D", target, level, xoffset, yoffset, zoffset, x, y, width, height)
+	b.boundTexture(textureBindingTarget(target))
+}
+
+func (b *Backend) CreateBuffer() wasmgl.Buffer {
+	b.record("CreateBuffer")
+	if b.contextLost {
+		return wasmgl.NilBuffer
+	}
+	return wasmgl.NewBuffer(b.createObject(bufferKind))
+}
+
+func (b *Backend) CreateFramebuffer() wasmgl.Framebuffer {
+	b.record("CreateFramebuffer")
+	if b.contextLost {
+		return wasmgl.NilFramebuffer
+	}
+	return wasmgl.NewFramebuffer(b.createObject(framebufferKind))
+}
+
+func (b *Backend) CreateProgram() wasmgl.Program {
+	b.record("CreateProgram")
+	if b.contextLost {
+		return wasmgl.NilProgram
+	}
+	obj := b.createObject(programKind)
+	obj.attribs = make(map[string]wasmgl.GLint)
+	obj.uniformBlocks = make(map[string]wasmgl.GLuint)
+	return wasmgl.NewProgram(obj)
+}
+
+func (b *Backend) CreateRenderbuffer() wasmgl.Renderbuffer {
+	b.record("CreateRenderbuffer")
+	if b.contextLost {
+		return wasmgl.NilRenderbuffer
+	}
+	return wasmgl.NewRenderbuffer(b.createObject(renderbufferKind))
+}
+
+func (b *Backend) CreateSampler() wasmgl.Sampler {
+	b.record("CreateSampler")
+	if b.contextLost {
+		return wasmgl.NilSampler
+	}
+	obj := b.createObject(samplerKind)
+	obj.parameters = make(map[wasmgl.GLenum]any)
+	return wasmgl.NewSampler(obj)
+}
+
+func (b *Backend) CreateShader(shaderType wasmgl.GLenum) wasmgl.Shader {
+	b.record("CreateShader", shaderType)
+	if b.contextLost {
+		return wasmgl.NilShader
+	}
+	if shaderType != wasmgl.VERTEX_SHADER && shaderType != wasmgl.FRAGMENT_SHADER {
+		b.setError(wasmgl.INVALID_ENUM)
+		return wasmgl.NilShader
+	}
+	obj := b.createObject(shaderKind)
+	obj.shaderType = shaderType
+	return wasmgl.NewShader(obj)
+}
+
+func (b *Backend) CreateTexture() wasmgl.Texture {
+	b.record("CreateTexture")
+	if b.contextLost {
+		return wasmgl.NilTexture
+	}
+	return wasmgl.NewTexture(b.createObject(textureKind))
+}
+
+func (b *Backend) CreateVertexArray() wasmgl.VertexArray {
+	b.record("CreateVertexArray")
+	if b.contextLost {
+		return wasmgl.NilVertexArray
+	}
+	return wasmgl.NewVertexArray(b.createObject(vertexArrayKind))
+}
+
+func (b *Backend) CullFace(mode wasmgl.GLenum) {
+	b.record("CullFace", mode)
+}
+
+func (b *Backend) DeleteBuffer(buffer wasmgl.Buffer) {
+	b.record("DeleteBuffer", buffer)
+	b.deleteObject(buffer, bufferKind)
+}
+
+func (b *Backend) DeleteFramebuffer(framebuffer wasmgl.Framebuffer) {
+	b.record("DeleteFramebuffer", framebuffer)
+	b.deleteObject(framebuffer, framebufferKind)
+}
+
+func (b *Backend) DeleteProgram(program wasmgl.Program) {
+	b.record("DeleteProgram", program)
+	b.deleteObject(program, programKind)
+}
+
+func (b *Backend) DeleteRenderbuffer(renderbuffer wasmgl.Renderbuffer) {
+	b.record("DeleteRenderbuffer", renderbuffer)
+	b.deleteObject(renderbuffer, renderbufferKind)
+}
+
+func (b *Backend) DeleteSampler(sampler wasmgl.Sampler) {
+	b.record("DeleteSampler", sampler)
+	b.deleteObject(sampler, samplerKind)
+}
+
+func (b *Backend) DeleteShader(shader wasmgl.Shader) {
+	b.record("DeleteShader", shader)
+	b.deleteObject(shader, shaderKind)
+}
+
+func (b *Backend) DeleteSync(sync wasmgl.Sync) {
+	b.record("DeleteSync", sync)
+	b.deleteObject(sync, syncKind)
+}
+
+func (b *Backend) DeleteTexture(texture wasmgl.Texture) {
+	b.record("DeleteTexture", texture)
+	b.deleteObject(texture, textureKind)
+}
+
+func (b *Backend) DeleteVertexArray(array wasmgl.VertexArray) {
+	b.record("DeleteVertexArray", array)
+	b.deleteObject(array, vertexArrayKind)
+}
+
+// deleteObject marks the object of the specified handle as deleted and
+// removes any bindings to it. Deleting an already deleted object is
+// silently ignored, like in WebGL.
+func (b *Backend) deleteObject(handle interface{ Value() any }, kind objectKind) {
+	obj, ok := handle.Value().(*object)
+	if !ok || obj.deleted {
+		return
+	}
+	if obj.kind != kind {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return
+	}
+	obj.deleted = true
+	b.unbind(obj)
+}
+
+func (b *Backend) DepthFunc(fn wasmgl.GLenum) {
+	b.record("DepthFunc", fn)
+}
+
+func (b *Backend) DepthMask(mask wasmgl.GLboolean) {
+	b.record("DepthMask", mask)
+}
+
+func (b *Backend) DepthRange(zNear, zFar wasmgl.GLclampf) {
+	b.record("DepthRange", zNear, zFar)
+	if zNear > zFar {
+		b.setError(wasmgl.INVALID_OPERATION)
+	}
+}
+
+func (b *Backend) DetachShader(program wasmgl.Program, shader wasmgl.Shader) {
+	b.record("DetachShader", program, shader)
+	programObj, ok := b.resolve(program, programKind)
+	if !ok {
+		return
+	}
+	shaderObj, ok := shader.Value().(*object)
+	if programObj == nil || !ok {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	for i, attached := range programObj.shaders {
+		if attached == shaderObj {
+			programObj.shaders = append(programObj.shaders[:i], programObj.shaders[i+1:]...)
+			return
+		}
+	}
+	b.setError(wasmgl.INVALID_OPERATION)
+}
+
+func (b *Backend) Disable(cap wasmgl.GLenum) {
+	b.record("Disable", cap)
+	if !isCapability(cap) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	b.capabilities[cap] = false
+}
+
+func (b *Backend) DisableVertexAttribArray(index wasmgl.GLuint) {
+	b.record("DisableVertexAttribArray", index)
+	b.currentVertexArrayState().enabledAttribs[index] = false
+}
+
+func (b *Backend) DrawArrays(mode wasmgl.GLenum, first wasmgl.GLint, count wasmgl.GLsizei) {
+	b.record("DrawArrays", mode, first, count)
+	b.checkDraw(first, count, 1, false)
+}
+
+func (b *Backend) DrawArraysInstanced(mode wasmgl.GLenum, first wasmgl.GLint, count, instanceCount wasmgl.GLsizei) {
+	b.record("DrawArraysInstanced", mode, first, count, instanceCount)
+	b.checkDraw(first, count, instanceCount, false)
+}
+
+func (b *Backend) DrawBuffers(buffers []wasmgl.GLenum) {
+	b.record("DrawBuffers", buffers)
+}
+
+func (b *Backend) DrawElements(mode wasmgl.GLenum, count wasmgl.GLsizei, dtype wasmgl.GLenum, offset wasmgl.GLintptr) {
+	b.record("DrawElements", mode, count, dtype, offset)
+	b.checkDraw(0, count, 1, true)
+}
+
+func (b *Backend) DrawElementsInstanced(mode wasmgl.GLenum, count wasmgl.GLsizei, pType wasmgl.GLenum, offset wasmgl.GLintptr, instanceCount wasmgl.GLsizei) {
+	b.record("DrawElementsInstanced", mode, count, pType, offset, instanceCount)
+	b.checkDraw(0, count, instanceCount, true)
+}
+
+func (b *Backend) checkDraw(first wasmgl.GLint, count, instanceCount wasmgl.GLsizei, indexed bool) {
+	if first < 0 || count < 0 || instanceCount < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	if b.program == nil {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return
+	}
+	if indexed && b.currentVertexArrayState().elementArrayBuffer == nil {
+		b.setError(wasmgl.INVALID_OPERATION)
+	}
+}
+
+func (b *Backend) DrawingBufferColorSpace() wasmgl.ColorSpace {
+	b.record("DrawingBufferColorSpace")
+	return b.drawingBufferColorSpace
+}
+
+func (b *Backend) DrawingBufferFormat() wasmgl.GLenum {
+	b.record("DrawingBufferFormat")
+	return b.drawingBufferFormat
+}
+
+func (b *Backend) DrawingBufferHeight() int {
+	b.record("DrawingBufferHeight")
+	return b.drawingBufferHeight
+}
+
+func (b *Backend) DrawingBufferStorage(internalFormat wasmgl.GLenum, width, height wasmgl.GLsizei) {
+	b.record("DrawingBufferStorage", internalFormat, width, height)
+	switch internalFormat {
+	case wasmgl.RGBA8, wasmgl.SRGB8_ALPHA8, wasmgl.RGBA16F:
+	default:
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	if width < 0 || height < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	b.drawingBufferFormat = internalFormat
+	b.drawingBufferWidth = int(width)
+	b.drawingBufferHeight = int(height)
+}
+
+func (b *Backend) DrawingBufferWidth() int {
+	b.record("DrawingBufferWidth")
+	return b.drawingBufferWidth
+}
+
+func (b *Backend) Enable(cap wasmgl.GLenum) {
+	b.record("Enable", cap)
+	if !isCapability(cap) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	b.capabilities[cap] = true
+}
+
+func (b *Backend) EnableVertexAttribArray(index wasmgl.GLuint) {
+	b.record("EnableVertexAttribArray", index)
+	b.currentVertexArrayState().enabledAttribs[index] = true
+}
+
+func (b *Backend) Finish() {
+	b.record("Finish")
+}
+
+func (b *Backend) Flush() {
+	b.record("Flush")
+}
+
+func (b *Backend) FramebufferRenderbuffer(target, attachment, renderbufferTarget wasmgl.GLenum, renderbuffer wasmgl.Renderbuffer) {
+	b.record("FramebufferRenderbuffer", target, attachment, renderbufferTarget, renderbuffer)
+	if !isFramebufferTarget(target) || renderbufferTarget != wasmgl.RENDERBUFFER {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	if _, ok := b.resolve(renderbuffer, renderbufferKind); !ok {
+		return
+	}
+	framebuffer := b.drawFramebuffer
+	if target == wasmgl.READ_FRAMEBUFFER {
+		framebuffer = b.readFramebuffer
+	}
+	if framebuffer == nil {
+		b.setError(wasmgl.INVALID_OPERATION)
+	}
+}
+
+func (b *Backend) FramebufferTexture2D(target, attachment, texTarget wasmgl.GLenum, texture wasmgl.Texture, level wasmgl.GLint) {
+	b.record("FramebufferTexture2D", target, attachment, texTarget, texture, level)
+	if texTarget != wasmgl.TEXTURE_2D && textureBindingTarget(texTarget) != wasmgl.TEXTURE_CUBE_MAP {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	obj, ok := b.checkFramebufferAttachment(target, texture)
+	if ok && obj != nil && obj.target != textureBindingTarget(texTarget) {
+		b.setError(wasmgl.INVALID_OPERATION)
+	}
+}
+
+func (b *Backend) FramebufferTextureLayer(target, attachment wasmgl.GLenum, texture wasmgl.Texture, level, layer wasmgl.GLint) {
+	b.record("FramebufferTextureLayer", target, attachment, texture, level, layer)
+	obj, ok := b.checkFramebufferAttachment(target, texture)
+	if ok && obj != nil && obj.target != wasmgl.TEXTURE_3D && obj.target != wasmgl.TEXTURE_2D_ARRAY {
+		b.setError(wasmgl.INVALID_OPERATION)
+	}
+}
+
+func (b *Backend) checkFramebufferAttachment(target wasmgl.GLenum, texture wasmgl.Texture) (*object, bool) {
+	if !isFramebufferTarget(target) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return nil, false
+	}
+	obj, ok := b.resolve(texture, textureKind)
+	if !ok {
+		return nil, false
+	}
+	framebuffer := b.drawFramebuffer
+	if target == wasmgl.READ_FRAMEBUFFER {
+		framebuffer = b.readFramebuffer
+	}
+	if framebuffer == nil {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return nil, false
+	}
+	return obj, true
+}
+
+func (b *Backend) FrontFace(mode wasmgl.GLenum) {
+	b.record("FrontFace", mode)
+}
+
+func (b *Backend) FenceSync(condition wasmgl.GLenum, flags wasmgl.GLbitfield) wasmgl.Sync {
+	b.record("FenceSync", condition, flags)
+	if b.contextLost {
+		return wasmgl.NilSync
+	}
+	if condition != wasmgl.SYNC_GPU_COMMANDS_COMPLETE {
+		b.setError(wasmgl.INVALID_ENUM)
+		return wasmgl.NilSync
+	}
+	if flags != 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return wasmgl.NilSync
+	}
+	obj := b.createObject(syncKind)
+	obj.signaled = !b.manualFences
+	return wasmgl.NewSync(obj)
+}
+
+func (b *Backend) GenerateMipmap(target wasmgl.GLenum) {
+	b.record("GenerateMipmap", target)
+	b.boundTexture(target)
+}
+
+func (b *Backend) GetAttachedShaders(program wasmgl.Program) []wasmgl.Shader {
+	b.record("GetAttachedShaders", program)
+	obj, ok := b.resolve(program, programKind)
+	if !ok || obj == nil {
+		return nil
+	}
+	shaders := make([]wasmgl.Shader, len(obj.shaders))
+	for i, shader := range obj.shaders {
+		shaders[i] = wasmgl.NewShader(shader.handle())
+	}
+	return shaders
+}
+
+func (b *Backend) GetAttribLocation(program wasmgl.Program, name string) wasmgl.GLint {
+	b.record("GetAttribLocation", program, name)
+	obj, ok := b.resolve(program, programKind)
+	if !ok || obj == nil || !obj.linked {
+		if ok {
+			b.setError(wasmgl.INVALID_OPERATION)
+		}
+		return -1
+	}
+	location, ok := obj.attribs[name]
+	if !ok {
+		location = wasmgl.GLint(len(obj.attribs))
+		obj.attribs[name] = location
+	}
+	return location
+}
+
+func (b *Backend) GetBufferSubData(target wasmgl.GLenum, srcOffset wasmgl.GLintptr, data []byte) {
+	b.record("GetBufferSubData", target, srcOffset, len(data))
+	buffer, ok := b.boundBuffer(target)
+	if !ok {
+		return
+	}
+	if srcOffset < 0 || int(srcOffset)+len(data) > len(buffer.data) {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	copy(data, buffer.data[srcOffset:])
+}
+
+func (b *Backend) GetError() wasmgl.GLenum {
+	b.record("GetError")
+	err := b.err
+	b.err = noError
+	return err
+}
+
+func (b *Backend) GetExtension(name string) any {
+	b.record("GetExtension", name)
+	if !b.extensions[name] {
+		return nil
+	}
+	return true
+}
+
+func (b *Backend) GetParameter(name wasmgl.GLenum) wasmgl.Any {
+	b.record("GetParameter", name)
+	switch name {
+	case wasmgl.ACTIVE_TEXTURE:
+		return wasmgl.NewAny(b.activeTexture)
+	case wasmgl.CURRENT_PROGRAM:
+		return wasmgl.NewAny(wasmgl.NewProgram(b.program.handle()))
+	case wasmgl.ARRAY_BUFFER_BINDING:
+		return wasmgl.NewAny(b.BoundBuffer(wasmgl.ARRAY_BUFFER))
+	case wasmgl.ELEMENT_ARRAY_BUFFER_BINDING:
+		return wasmgl.NewAny(b.BoundBuffer(wasmgl.ELEMENT_ARRAY_BUFFER))
+	case wasmgl.UNIFORM_BUFFER_BINDING:
+		return wasmgl.NewAny(b.BoundBuffer(wasmgl.UNIFORM_BUFFER))
+	case wasmgl.TEXTURE_BINDING_2D:
+		return wasmgl.NewAny(b.BoundTexture(b.activeTexture, wasmgl.TEXTURE_2D))
+	case wasmgl.TEXTURE_BINDING_CUBE_MAP:
+		return wasmgl.NewAny(b.BoundTexture(b.activeTexture, wasmgl.TEXTURE_CUBE_MAP))
+	case wasmgl.TEXTURE_BINDING_3D:
+		return wasmgl.NewAny(b.BoundTexture(b.activeTexture, wasmgl.TEXTURE_3D))
+	case wasmgl.TEXTURE_BINDING_2D_ARRAY:
+		return wasmgl.NewAny(b.BoundTexture(b.activeTexture, wasmgl.TEXTURE_2D_ARRAY))
+	case wasmgl.VERTEX_ARRAY_BINDING:
+		return wasmgl.NewAny(b.BoundVertexArray())
+	case wasmgl.DRAW_FRAMEBUFFER_BINDING:
+		return wasmgl.NewAny(b.BoundFramebuffer(wasmgl.DRAW_FRAMEBUFFER))
+	case wasmgl.READ_FRAMEBUFFER_BINDING:
+		return wasmgl.NewAny(b.BoundFramebuffer(wasmgl.READ_FRAMEBUFFER))
+	case wasmgl.VIEWPORT:
+		return wasmgl.NewAny(b.viewport[:])
+	case wasmgl.SCISSOR_BOX:
+		return wasmgl.NewAny(b.scissor[:])
+	}
+	if isCapability(name) {
+		return wasmgl.NewAny(b.capabilities[name])
+	}
+	if value, ok := b.parameters[name]; ok {
+		return wasmgl.NewAny(value)
+	}
+	b.setError(wasmgl.INVALID_ENUM)
+	return wasmgl.NewAny(nil)
+}
+
+func (b *Backend) GetProgramInfoLog(program wasmgl.Program) string {
+	b.record("GetProgramInfoLog", program)
+	obj, ok := b.resolve(program, programKind)
+	if !ok || obj == nil || obj.linked {
+		return ""
+	}
+	return "program is missing compiled vertex or fragment shader"
+}
+
+func (b *Backend) GetProgramParameter(program wasmgl.Program, pname wasmgl.GLenum) wasmgl.Any {
+	b.record("GetProgramParameter", program, pname)
+	obj, ok := b.resolve(program, programKind)
+	if !ok || obj == nil {
+		return wasmgl.NewAny(nil)
+	}
+	switch pname {
+	case wasmgl.LINK_STATUS:
+		return wasmgl.NewAny(obj.linked)
+	case wasmgl.DELETE_STATUS:
+		return wasmgl.NewAny(obj.deleted)
+	case wasmgl.VALIDATE_STATUS:
+		return wasmgl.NewAny(obj.validated)
+	case wasmgl.ATTACHED_SHADERS:
+		return wasmgl.NewAny(len(obj.shaders))
+	default:
+		b.setError(wasmgl.INVALID_ENUM)
+		return wasmgl.NewAny(nil)
+	}
+}
+
+func (b *Backend) GetSamplerParameter(sampler wasmgl.Sampler, pname wasmgl.GLenum) wasmgl.Any {
+	b.record("GetSamplerParameter", sampler, pname)
+	obj, ok := b.resolve(sampler, samplerKind)
+	if !ok || obj == nil {
+		return wasmgl.NewAny(nil)
+	}
+	return wasmgl.NewAny(obj.parameters[pname])
+}
+
+func (b *Backend) GetShaderInfoLog(shader wasmgl.Shader) string {
+	b.record("GetShaderInfoLog", shader)
+	return ""
+}
+
+func (b *Backend) GetShaderParameter(shader wasmgl.Shader, pname wasmgl.GLenum) wasmgl.Any {
+	b.record("GetShaderParameter", shader, pname)
+	obj, ok := b.resolve(shader, shaderKind)
+	if !ok || obj == nil {
+		return wasmgl.NewAny(nil)
+	}
+	switch pname {
+	case wasmgl.COMPILE_STATUS:
+		return wasmgl.NewAny(obj.compiled)
+	case wasmgl.DELETE_STATUS:
+		return wasmgl.NewAny(obj.deleted)
+	case wasmgl.SHADER_TYPE:
+		return wasmgl.NewAny(obj.shaderType)
+	default:
+		b.setError(wasmgl.INVALID_ENUM)
+		return wasmgl.NewAny(nil)
+	}
+}
+
+func (b *Backend) GetShaderPrecisionFormat(shaderType, precisionType wasmgl.GLenum) wasmgl.ShaderPrecisionFormat {
+	b.record("GetShaderPrecisionFormat", shaderType, precisionType)
+	if shaderType != wasmgl.VERTEX_SHADER && shaderType != wasmgl.FRAGMENT_SHADER {
+		b.setError(wasmgl.INVALID_ENUM)
+		return wasmgl.ShaderPrecisionFormat{}
+	}
+	if format, ok := b.precisions[precisionKey{shaderType: shaderType, precisionType: precisionType}]; ok {
+		return format
+	}
+	switch precisionType {
+	case wasmgl.LOW_FLOAT, wasmgl.MEDIUM_FLOAT, wasmgl.HIGH_FLOAT:
+		return wasmgl.ShaderPrecisionFormat{RangeMin: 127, RangeMax: 127, Precision: 23}
+	case wasmgl.LOW_INT, wasmgl.MEDIUM_INT, wasmgl.HIGH_INT:
+		return wasmgl.ShaderPrecisionFormat{RangeMin: 31, RangeMax: 30, Precision: 0}
+	default:
+		b.setError(wasmgl.INVALID_ENUM)
+		return wasmgl.ShaderPrecisionFormat{}
+	}
+}
+
+func (b *Backend) GetShaderSource(shader wasmgl.Shader) string {
+	b.record("GetShaderSource", shader)
+	obj, ok := b.resolve(shader, shaderKind)
+	if !ok || obj == nil {
+		return ""
+	}
+	return obj.source
+}
+
+func (b *Backend) GetSyncParameter(sync wasmgl.Sync, pname wasmgl.GLenum) wasmgl.Any {
+	b.record("GetSyncParameter", sync, pname)
+	obj, ok := b.resolve(sync, syncKind)
+	if !ok || obj == nil {
+		return wasmgl.NewAny(nil)
+	}
+	switch pname {
+	case wasmgl.OBJECT_TYPE:
+		return wasmgl.NewAny(wasmgl.GLenum(wasmgl.SYNC_FENCE))
+	case wasmgl.SYNC_STATUS:
+		if !obj.signaled {
+			return wasmgl.NewAny(wasmgl.GLenum(wasmgl.UNSIGNALED))
+		}
+		return wasmgl.NewAny(wasmgl.GLenum(wasmgl.SIGNALED))
+	case wasmgl.SYNC_CONDITION:
+		return wasmgl.NewAny(wasmgl.GLenum(wasmgl.SYNC_GPU_COMMANDS_COMPLETE))
+	case wasmgl.SYNC_FLAGS:
+		return wasmgl.NewAny(0)
+	default:
+		b.setError(wasmgl.INVALID_ENUM)
+		return wasmgl.NewAny(nil)
+	}
+}
+
+func (b *Backend) GetUniformBlockIndex(program wasmgl.Program, name string) wasmgl.GLuint {
+	b.record("GetUniformBlockIndex", program, name)
+	obj, ok := b.resolve(program, programKind)
+	if !ok || obj == nil || !obj.linked {
+		if ok {
+			b.setError(wasmgl.INVALID_OPERATION)
+		}
+		return wasmgl.INVALID_INDEX
+	}
+	index, ok := obj.uniformBlocks[name]
+	if !ok {
+		index = wasmgl.GLuint(len(obj.uniformBlocks))
+		obj.uniformBlocks[name] = index
+	}
+	return index
+}
+
+func (b *Backend) GetUniformLocation(program wasmgl.Program, name string) wasmgl.UniformLocation {
+	b.record("GetUniformLocation", program, name)
+	obj, ok := b.resolve(program, programKind)
+	if !ok || obj == nil || !obj.linked {
+		if ok {
+			b.setError(wasmgl.INVALID_OPERATION)
+		}
+		return wasmgl.NilUniformLocation
+	}
+	location := b.createObject(uniformLocationKind)
+	location.program = obj
+	location.name = name
+	return wasmgl.NewUniformLocation(location)
+}
+
+func (b *Backend) Hint(target, mode wasmgl.GLenum) {
+	b.record("Hint", target, mode)
+}
+
+func (b *Backend) InvalidateFramebuffer(target wasmgl.GLenum, attachments []wasmgl.GLenum) {
+	b.record("InvalidateFramebuffer", target, attachments)
+	if !isFramebufferTarget(target) {
+		b.setError(wasmgl.INVALID_ENUM)
+	}
+}
+
+func (b *Backend) InvalidateSubFramebuffer(target wasmgl.GLenum, attachments []wasmgl.GLenum, x, y wasmgl.GLint, width, height wasmgl.GLsizei) {
+	b.record("InvalidateSubFramebuffer", target, attachments, x, y, width, height)
+	if !isFramebufferTarget(target) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	if width < 0 || height < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+func (b *Backend) IsBuffer(buffer wasmgl.Buffer) bool {
+	b.record("IsBuffer", buffer)
+	return isObject(buffer, bufferKind)
+}
+
+func isObject(handle interface{ Value() any }, kind objectKind) bool {
+	obj, ok := handle.Value().(*object)
+	return ok && obj.kind == kind && !obj.deleted
+}
+
+func (b *Backend) IsContextLost() bool {
+	b.record("IsContextLost")
+	return b.contextLost
+}
+
+func (b *Backend) IsEnabled(cap wasmgl.GLenum) bool {
+	b.record("IsEnabled", cap)
+	if !isCapability(cap) {
+		b.setError(wasmgl.INVALID_ENUM)
+		return false
+	}
+	return b.capabilities[cap]
+}
+
+func (b *Backend) IsFramebuffer(framebuffer wasmgl.Framebuffer) bool {
+	b.record("IsFramebuffer", framebuffer)
+	return isObject(framebuffer, framebufferKind)
+}
+
+func (b *Backend) IsProgram(program wasmgl.Program) bool {
+	b.record("IsProgram", program)
+	return isObject(program, programKind)
+}
+
+func (b *Backend) IsQuery(query wasmgl.Query) bool {
+	b.record("IsQuery", query)
+	return isObject(query, queryKind)
+}
+
+func (b *Backend) IsRenderbuffer(renderbuffer wasmgl.Renderbuffer) bool {
+	b.record("IsRenderbuffer", renderbuffer)
+	return isObject(renderbuffer, renderbufferKind)
+}
+
+func (b *Backend) IsSampler(sampler wasmgl.Sampler) bool {
+	b.record("IsSampler", sampler)
+	return isObject(sampler, samplerKind)
+}
+
+func (b *Backend) IsShader(shader wasmgl.Shader) bool {
+	b.record("IsShader", shader)
+	return isObject(shader, shaderKind)
+}
+
+func (b *Backend) IsSync(sync wasmgl.Sync) bool {
+	b.record("IsSync", sync)
+	return isObject(sync, syncKind)
+}
+
+func (b *Backend) IsTexture(texture wasmgl.Texture) bool {
+	b.record("IsTexture", texture)
+	return isObject(texture, textureKind)
+}
+
+func (b *Backend) IsVertexArray(array wasmgl.VertexArray) bool {
+	b.record("IsVertexArray", array)
+	return isObject(array, vertexArrayKind)
+}
+
+func (b *Backend) LineWidth(width wasmgl.GLfloat) {
+	b.record("LineWidth", width)
+	if width <= 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+func (b *Backend) LinkProgram(program wasmgl.Program) {
+	b.record("LinkProgram", program)
+	obj, ok := b.resolve(program, programKind)
+	if !ok || obj == nil {
+		return
+	}
+	var hasVertex, hasFragment bool
+	for _, shader := range obj.shaders {
+		switch {
+		case !shader.compiled:
+		case shader.shaderType == wasmgl.VERTEX_SHADER:
+			hasVertex = true
+		case shader.shaderType == wasmgl.FRAGMENT_SHADER:
+			hasFragment = true
+		}
+	}
+	obj.linked = hasVertex && hasFragment
+	obj.validated = false
+	clear(obj.attribs)
+	clear(obj.uniformBlocks)
+}
+
+func (b *Backend) PixelStorei(pname wasmgl.GLenum, param wasmgl.GLint) {
+	b.record("PixelStorei", pname, param)
+}
+
+func (b *Backend) PolygonOffset(factor, units wasmgl.GLfloat) {
+	b.record("PolygonOffset", factor, units)
+}
+
+func (b *Backend) ReadBuffer(src wasmgl.GLenum) {
+	b.record("ReadBuffer", src)
+	switch {
+	case src == wasmgl.NONE:
+	case src == wasmgl.BACK:
+		if b.readFramebuffer != nil {
+			b.setError(wasmgl.INVALID_OPERATION)
+		}
+	case src >= wasmgl.COLOR_ATTACHMENT0 && src <= wasmgl.COLOR_ATTACHMENT15:
+		if b.readFramebuffer == nil {
+			b.setError(wasmgl.INVALID_OPERATION)
+		}
+	default:
+		b.setError(wasmgl.INVALID_ENUM)
+	}
+}
+
+func (b *Backend) ReadPixels(x, y wasmgl.GLint, width, height wasmgl.GLsizei, format, dtype wasmgl.GLenum, offset wasmgl.GLintptr) {
+	b.record("ReadPixels", x, y, width, height, format, dtype, offset)
+	if width < 0 || height < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+func (b *Backend) ReadPixelsData(x, y wasmgl.GLint, width, height wasmgl.GLsizei, format, dtype wasmgl.GLenum, data []byte) {
+	b.record("ReadPixelsData", x, y, width, height, format, dtype, len(data))
+	if width < 0 || height < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	if b.buffers[wasmgl.PIXEL_PACK_BUFFER] != nil {
+		b.setError(wasmgl.INVALID_OPERATION)
+	}
+}
+
+func (b *Backend) RenderbufferStorage(target, internalFormat wasmgl.GLenum, width, height wasmgl.GLsizei) {
+	b.record("RenderbufferStorage", target, internalFormat, width, height)
+	b.renderbufferStorage(target, 0, width, height)
+}
+
+func (b *Backend) renderbufferStorage(target wasmgl.GLenum, samples, width, height wasmgl.GLsizei) {
+	if target != wasmgl.RENDERBUFFER {
+		b.setError(wasmgl.INVALID_ENUM)
+		return
+	}
+	if b.renderbuffer == nil {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return
+	}
+	if samples < 0 || width < 0 || height < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	if b.outOfMemory {
+		b.setError(wasmgl.OUT_OF_MEMORY)
+	}
+}
+
+func (b *Backend) RenderbufferStorageMultisample(target wasmgl.GLenum, samples wasmgl.GLsizei, internalFormat wasmgl.GLenum, width, height wasmgl.GLsizei) {
+	b.record("RenderbufferStorageMultisample", target, samples, internalFormat, width, height)
+	b.renderbufferStorage(target, samples, width, height)
+}
+
+func (b *Backend) SampleCoverage(value wasmgl.GLclampf, invert wasmgl.GLboolean) {
+	b.record("SampleCoverage", value, invert)
+}
+
+func (b *Backend) SamplerParameterf(sampler wasmgl.Sampler, pname wasmgl.GLenum, param wasmgl.GLfloat) {
+	b.record("SamplerParameterf", sampler, pname, param)
+	if obj, ok := b.resolve(sampler, samplerKind); ok && obj != nil {
+		obj.parameters[pname] = param
+	}
+}
+
+func (b *Backend) SamplerParameteri(sampler wasmgl.Sampler, pname wasmgl.GLenum, param wasmgl.GLint) {
+	b.record("SamplerParameteri", sampler, pname, param)
+	if obj, ok := b.resolve(sampler, samplerKind); ok && obj != nil {
+		obj.parameters[pname] = param
+	}
+}
+
+func (b *Backend) Scissor(x, y wasmgl.GLint, width, height wasmgl.GLsizei) {
+	b.record("Scissor", x, y, width, height)
+	if width < 0 || height < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	b.scissor = [4]wasmgl.GLint{x, y, width, height}
+}
+
+func (b *Backend) SetDrawingBufferColorSpace(colorSpace wasmgl.ColorSpace) {
+	b.record("SetDrawingBufferColorSpace", colorSpace)
+	if isColorSpace(colorSpace) {
+		b.drawingBufferColorSpace = colorSpace
+	}
+}
+
+func (b *Backend) SetUnpackColorSpace(colorSpace wasmgl.ColorSpace) {
+	b.record("SetUnpackColorSpace", colorSpace)
+	if isColorSpace(colorSpace) {
+		b.unpackColorSpace = colorSpace
+	}
+}
+
+func (b *Backend) ShaderSource(shader wasmgl.Shader, source string) {
+	b.record("ShaderSource", shader, source)
+	if obj, ok := b.resolve(shader, shaderKind); ok && obj != nil {
+		obj.source = source
+	}
+}
+
+func (b *Backend) StencilFunc(fun wasmgl.GLenum, ref wasmgl.GLint, mask wasmgl.GLuint) {
+	b.record("StencilFunc", fun, ref, mask)
+}
+
+func (b *Backend) StencilFuncSeparate(face, fun wasmgl.GLenum, ref wasmgl.GLint, mask wasmgl.GLuint) {
+	b.record("StencilFuncSeparate", face, fun, ref, mask)
+}
+
+func (b *Backend) StencilMask(mask wasmgl.GLuint) {
+	b.record("StencilMask", mask)
+}
+
+func (b *Backend) StencilMaskSeparate(face wasmgl.GLenum, mask wasmgl.GLuint) {
+	b.record("StencilMaskSeparate", face, mask)
+}
+
+func (b *Backend) StencilOp(fail, zfail, zpass wasmgl.GLenum) {
+	b.record("StencilOp", fail, zfail, zpass)
+}
+
+func (b *Backend) StencilOpSeparate(face, fail, zfail, zpass wasmgl.GLenum) {
+	b.record("StencilOpSeparate", face, fail, zfail, zpass)
+}
+
+func (b *Backend) TexImage2D(target wasmgl.GLenum, level, internalFormat wasmgl.GLint, width, height wasmgl.GLsizei, border wasmgl.GLint, format, dtype wasmgl.GLenum, data []byte) {
+	b.record("TexImage2D", target, level, internalFormat, width, height, border, format, dtype, data)
+	if b.checkUnpackSource(false, 0) {
+		b.texImage(target, width, height, 1, border)
+	}
+}
+
+func (b *Backend) texImage(target wasmgl.GLenum, width, height, depth wasmgl.GLsizei, border wasmgl.GLint) {
+	texture, ok := b.boundTexture(textureBindingTarget(target))
+	if !ok {
+		return
+	}
+	if texture.immutable {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return
+	}
+	if width < 0 || height < 0 || depth < 0 || border != 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+// checkUnpackSource verifies that a PIXEL_UNPACK_BUFFER is bound if, and
+// only if, the upload sources its data from one.
+func (b *Backend) checkUnpackSource(fromBuffer bool, offset wasmgl.GLintptr) bool {
+	bound := b.buffers[wasmgl.PIXEL_UNPACK_BUFFER] != nil
+	if bound != fromBuffer {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return false
+	}
+	if offset < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return false
+	}
+	return true
+}
+
+func (b *Backend) TexImage2DOffset(target wasmgl.GLenum, level, internalFormat wasmgl.GLint, width, height wasmgl.GLsizei, border wasmgl.GLint, format, dtype wasmgl.GLenum, offset wasmgl.GLintptr) {
+	b.record("TexImage2DOffset", target, level, internalFormat, width, height, border, format, dtype, offset)
+	if b.checkUnpackSource(true, offset) {
+		b.texImage(target, width, height, 1, border)
+	}
+}
+
+func (b *Backend) TexImage3D(target wasmgl.GLenum, level, internalFormat wasmgl.GLint, width, height, depth wasmgl.GLsizei, border wasmgl.GLint, format, dtype wasmgl.GLenum, data []byte) {
+	b.record("TexImage3D", target, level, internalFormat, width, height, depth, border, format, dtype, data)
+	if b.checkUnpackSource(false, 0) {
+		b.texImage(target, width, height, depth, border)
+	}
+}
+
+func (b *Backend) TexImage3DOffset(target wasmgl.GLenum, level, internalFormat wasmgl.GLint, width, height, depth wasmgl.GLsizei, border wasmgl.GLint, format, dtype wasmgl.GLenum, offset wasmgl.GLintptr) {
+	b.record("TexImage3DOffset", target, level, internalFormat, width, height, depth, border, format, dtype, offset)
+	if b.checkUnpackSource(true, offset) {
+		b.texImage(target, width, height, depth, border)
+	}
+}
+
+func (b *Backend) TexParameterf(target, pname wasmgl.GLenum, param wasmgl.GLfloat) {
+	b.record("TexParameterf", target, pname, param)
+	b.boundTexture(target)
+}
+
+func (b *Backend) TexStorage2D(target wasmgl.GLenum, levels wasmgl.GLsizei, internalFormat wasmgl.GLenum, width, height wasmgl.GLsizei) {
+	b.record("TexStorage2D", target, levels, internalFormat, width, height)
+	b.texStorage(target, levels, width, height, 1)
+}
+
+func (b *Backend) TexStorage3D(target wasmgl.GLenum, levels wasmgl.GLsizei, internalFormat wasmgl.GLenum, width, height, depth wasmgl.GLsizei) {
+	b.record("TexStorage3D", target, levels, internalFormat, width, height, depth)
+	b.texStorage(target, levels, width, height, depth)
+}
+
+func (b *Backend) texStorage(target wasmgl.GLenum, levels, width, height, depth wasmgl.GLsizei) {
+	texture, ok := b.boundTexture(target)
+	if !ok {
+		return
+	}
+	if texture.immutable {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return
+	}
+	if levels < 1 || width < 1 || height < 1 || depth < 1 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	if b.outOfMemory {
+		b.setError(wasmgl.OUT_OF_MEMORY)
+		return
+	}
+	texture.immutable = true
+}
+
+func (b *Backend) TexSubImage2D(target wasmgl.GLenum, level, xoffset, yoffset wasmgl.GLint, width, height wasmgl.GLsizei, format, dtype wasmgl.GLenum, data []byte) {
+	b.record("TexSubImage2D", target, level, xoffset, yoffset, width, height, format, dtype, data)
+	if b.checkUnpackSource(false, 0) {
+		b.boundTexture(textureBindingTarget(target))
+	}
+}
+
+func (b *Backend) TexSubImage2DOffset(target wasmgl.GLenum, level, xoffset, yoffset wasmgl.GLint, width, height wasmgl.GLsizei, format, dtype wasmgl.GLenum, offset wasmgl.GLintptr) {
+	b.record("TexSubImage2DOffset", target, level, xoffset, yoffset, width, height, format, dtype, offset)
+	if b.checkUnpackSource(true, offset) {
+		b.boundTexture(textureBindingTarget(target))
+	}
+}
+
+func (b *Backend) TexSubImage3D(target wasmgl.GLenum, level wasmgl.GLint, xoffset, yoffset, zoffset wasmgl.GLint, width, height, depth wasmgl.GLsizei, format, dtype wasmgl.GLenum, data []byte) {
+	b.record("TexSubImage3D", target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, data)
+	if b.checkUnpackSource(false, 0) {
+		b.boundTexture(textureBindingTarget(target))
+	}
+}
+
+func (b *Backend) TexParameteri(target, pname wasmgl.GLenum, param wasmgl.GLint) {
+	b.record("TexParameteri", target, pname, param)
+	b.boundTexture(target)
+}
+
+func (b *Backend) TexSubImage3DOffset(target wasmgl.GLenum, level, xoffset, yoffset, zoffset wasmgl.GLint, width, height, depth wasmgl.GLsizei, format, dtype wasmgl.GLenum, offset wasmgl.GLintptr) {
+	b.record("TexSubImage3DOffset", target, level, xoffset, yoffset, zoffset, width, height, depth, format, dtype, offset)
+	if b.checkUnpackSource(true, offset) {
+		b.boundTexture(textureBindingTarget(target))
+	}
+}
+
+func (b *Backend) Uniform1f(location wasmgl.UniformLocation, x wasmgl.GLfloat) {
+	b.record("Uniform1f", location, x)
+	b.checkUniformLocation(location)
+}
+
+func (b *Backend) Uniform1i(location wasmgl.UniformLocation, x wasmgl.GLint) {
+	b.record("Uniform1i", location, x)
+	b.checkUniformLocation(location)
+}
+
+func (b *Backend) Uniform2f(location wasmgl.UniformLocation, x, y wasmgl.GLfloat) {
+	b.record("Uniform2f", location, x, y)
+	b.checkUniformLocation(location)
+}
+
+func (b *Backend) Uniform2i(location wasmgl.UniformLocation, x, y wasmgl.GLint) {
+	b.record("Uniform2i", location, x, y)
+	b.checkUniformLocation(location)
+}
+
+func (b *Backend) Uniform3f(location wasmgl.UniformLocation, x, y, z wasmgl.GLfloat) {
+	b.record("Uniform3f", location, x, y, z)
+	b.checkUniformLocation(location)
+}
+
+func (b *Backend) Uniform3i(location wasmgl.UniformLocation, x, y, z wasmgl.GLint) {
+	b.record("Uniform3i", location, x, y, z)
+	b.checkUniformLocation(location)
+}
+
+func (b *Backend) Uniform4f(location wasmgl.UniformLocation, x, y, z, w wasmgl.GLfloat) {
+	b.record("Uniform4f", location, x, y, z, w)
+	b.checkUniformLocation(location)
+}
+
+func (b *Backend) Uniform4i(location wasmgl.UniformLocation, x, y, z, w wasmgl.GLint) {
+	b.record("Uniform4i", location, x, y, z, w)
+	b.checkUniformLocation(location)
+}
+
+func (b *Backend) UniformBlockBinding(program wasmgl.Program, index, binding wasmgl.GLuint) {
+	b.record("UniformBlockBinding", program, index, binding)
+	obj, ok := b.resolve(program, programKind)
+	if !ok {
+		return
+	}
+	if obj == nil || int(index) >= len(obj.uniformBlocks) {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+func (b *Backend) UniformMatrix4fv(location wasmgl.UniformLocation, transpose wasmgl.GLboolean, data []wasmgl.GLfloat) {
+	b.record("UniformMatrix4fv", location, transpose, data)
+	if !b.checkUniformLocation(location) {
+		return
+	}
+	if transpose || len(data)%16 != 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+// checkUniformLocation verifies that the specified location belongs to
+// the program that is currently in use. Null locations are silently
+// ignored, like in WebGL.
+func (b *Backend) checkUniformLocation(location wasmgl.UniformLocation) bool {
+	obj, ok := b.resolve(location, uniformLocationKind)
+	if !ok || obj == nil {
+		return false
+	}
+	if b.program == nil || obj.program != b.program {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return false
+	}
+	return true
+}
+
+func (b *Backend) UnpackColorSpace() wasmgl.ColorSpace {
+	b.record("UnpackColorSpace")
+	return b.unpackColorSpace
+}
+
+func (b *Backend) UseProgram(program wasmgl.Program) {
+	b.record("UseProgram", program)
+	obj, ok := b.resolve(program, programKind)
+	if !ok {
+		return
+	}
+	if obj != nil && !obj.linked {
+		b.setError(wasmgl.INVALID_OPERATION)
+		return
+	}
+	b.program = obj
+}
+
+func (b *Backend) ValidateProgram(program wasmgl.Program) {
+	b.record("ValidateProgram", program)
+	obj, ok := b.resolve(program, programKind)
+	if !ok || obj == nil {
+		return
+	}
+	obj.validated = obj.linked
+}
+
+func (b *Backend) VertexAttribIPointer(index wasmgl.GLuint, size wasmgl.GLint, dtype wasmgl.GLenum, stride wasmgl.GLsizei, offset wasmgl.GLintptr) {
+	b.record("VertexAttribIPointer", index, size, dtype, stride, offset)
+	b.checkVertexAttribPointer(size, stride, offset)
+}
+
+func (b *Backend) VertexAttribPointer(index wasmgl.GLuint, size wasmgl.GLint, dtype wasmgl.GLenum, normalized wasmgl.GLboolean, stride wasmgl.GLsizei, offset wasmgl.GLintptr) {
+	b.record("VertexAttribPointer", index, size, dtype, normalized, stride, offset)
+	b.checkVertexAttribPointer(size, stride, offset)
+}
+
+func (b *Backend) checkVertexAttribPointer(size wasmgl.GLint, stride wasmgl.GLsizei, offset wasmgl.GLintptr) {
+	if size < 1 || size > 4 || stride < 0 || stride > 255 || offset < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	if b.buffers[wasmgl.ARRAY_BUFFER] == nil && offset != 0 {
+		b.setError(wasmgl.INVALID_OPERATION)
+	}
+}
+
+func (b *Backend) Viewport(x, y wasmgl.GLint, width, height wasmgl.GLsizei) {
+	b.record("Viewport", x, y, width, height)
+	if width < 0 || height < 0 {
+		b.setError(wasmgl.INVALID_VALUE)
+		return
+	}
+	b.viewport = [4]wasmgl.GLint{x, y, width, height}
+}
+
+func (b *Backend) WaitSync(sync wasmgl.Sync, flags wasmgl.GLbitfield, timeout wasmgl.GLint64) {
+	b.record("WaitSync", sync, flags, timeout)
+	obj, ok := b.resolve(sync, syncKind)
+	if !ok || obj == nil || flags != 0 || timeout != wasmgl.TIMEOUT_IGNORED {
+		b.setError(wasmgl.INVALID_VALUE)
+	}
+}
+
+// textureBindingTarget returns the texture target that needs to be bound
+// for the specified image target (e.g. TEXTURE_CUBE_MAP for
+// TEXTURE_CUBE_MAP_POSITIVE_X).
+func textureBindingTarget(target wasmgl.GLenum) wasmgl.GLenum {
+	if target >= wasmgl.TEXTURE_CUBE_MAP_POSITIVE_X && target <= wasmgl.TEXTURE_CUBE_MAP_NEGATIVE_Z {
+		return wasmgl.TEXTURE_CUBE_MAP
+	}
+	return target
+}
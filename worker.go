@@ -0,0 +1,60 @@
+//go:build js && wasm
+
+package wasmgl
+
+import (
+	"fmt"
+	"syscall/js"
+)
+
+// TransferCanvasToWorker transfers the control over the specified canvas
+// element to the specified Web Worker. The resulting OffscreenCanvas is
+// posted to the worker as the property with the specified key of the
+// message, where it can be received through ReceiveOffscreenCanvas.
+//
+// This function needs to be called on the main thread and only once per
+// canvas.
+func TransferCanvasToWorker(worker, htmlCanvas js.Value, key string) error {
+	if htmlCanvas.Get("transferControlToOffscreen").IsUndefined() {
+		return fmt.Errorf("canvas does not support transferControlToOffscreen")
+	}
+	offscreenCanvas := htmlCanvas.Call("transferControlToOffscreen")
+	message := js.Global().Get("Object").New()
+	message.Set(key, offscreenCanvas)
+	worker.Call("postMessage", message, []any{offscreenCanvas})
+	return nil
+}
+
+// ReceiveOffscreenCanvas blocks until the current Web Worker receives a
+// message that holds an object under the specified key and returns that
+// object, which is expected to be an OffscreenCanvas (as posted by
+// TransferCanvasToWorker). Other messages are ignored.
+//
+// Messages that are dispatched before this function is called are not
+// seen, so the main thread should post the canvas only once the worker has
+// started waiting (e.g. after the worker has reported that it is ready).
+func ReceiveOffscreenCanvas(key string) js.Value {
+	received := make(chan js.Value, 1)
+	listener := js.FuncOf(func(this js.Value, args []js.Value) any {
+		if len(args) == 0 {
+			return nil
+		}
+		data := args[0].Get("data")
+		if data.Type() != js.TypeObject {
+			return nil
+		}
+		if offscreenCanvas := data.Get(key); offscreenCanvas.Type() == js.TypeObject {
+			select {
+			case received <- offscreenCanvas:
+			default:
+			}
+		}
+		return nil
+	})
+	defer listener.Release()
+
+	self := js.Global()
+	self.Call("addEventListener", "message", listener)
+	defer self.Call("removeEventListener", "message", listener)
+	return <-received
+}